@@ -15,6 +15,8 @@ import (
 	"upwork-job-api/server"
 )
 
+const jobListCollection = "job_list"
+
 func main() {
 	projectID := os.Getenv("FIREBASE_PROJECT_ID")
 	if projectID == "" {
@@ -24,15 +26,22 @@ func main() {
 	if serviceAccountPath == "" {
 		log.Fatal("FIREBASE_SERVICE_ACCOUNT_PATH is required")
 	}
-	collection := os.Getenv("FIRESTORE_COLLECTION")
-	if collection == "" {
-		collection = "individual_jobs"
+	defaultCollection := os.Getenv("FIRESTORE_COLLECTION")
+	if defaultCollection == "" {
+		defaultCollection = "individual_jobs"
 	}
 
 	limit := flag.Int("limit", 3, "number of documents to dump")
 	rawDump := flag.Bool("raw", false, "dump raw document data instead of transformed")
+	collection := flag.String("collection", defaultCollection, "collection to read (individual_jobs or job_list)")
 	flag.Parse()
 
+	// job_list holds flat search results rather than job page state
+	transform := server.DebugTransformDocument
+	if *collection == jobListCollection {
+		transform = server.TransformJobListDocument
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -42,7 +51,7 @@ func main() {
 	}
 	defer client.Close()
 
-	iter := client.Collection(collection).Documents(ctx)
+	iter := client.Collection(*collection).Documents(ctx)
 
 	found := 0
 	for found < *limit {
@@ -62,7 +71,7 @@ func main() {
 		if *rawDump {
 			payload["raw"] = doc.Data()
 		} else {
-			records, err := server.DebugTransformDocument(doc)
+			records, err := transform(doc)
 			if err != nil {
 				log.Printf("transform error for %s: %v", doc.Ref.ID, err)
 				continue
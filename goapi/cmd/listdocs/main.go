@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -21,11 +22,14 @@ func main() {
 	if serviceAccountPath == "" {
 		log.Fatal("FIREBASE_SERVICE_ACCOUNT_PATH is required")
 	}
-	collection := os.Getenv("FIRESTORE_COLLECTION")
-	if collection == "" {
-		collection = "individual_jobs"
+	defaultCollection := os.Getenv("FIRESTORE_COLLECTION")
+	if defaultCollection == "" {
+		defaultCollection = "individual_jobs"
 	}
 
+	collection := flag.String("collection", defaultCollection, "collection to list (individual_jobs or job_list)")
+	flag.Parse()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -35,7 +39,7 @@ func main() {
 	}
 	defer client.Close()
 
-	iter := client.Collection(collection).Documents(ctx)
+	iter := client.Collection(*collection).Documents(ctx)
 	defer iter.Stop()
 
 	count := 0
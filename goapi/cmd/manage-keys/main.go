@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ type APIKey struct {
 	CreatedAt  time.Time `json:"created_at" firestore:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at" firestore:"updated_at"`
 	IsActive   bool      `json:"is_active" firestore:"is_active"`
+	Scopes     []string  `json:"scopes,omitempty" firestore:"scopes,omitempty"`
 	KeyHash    string    `json:"key_hash" firestore:"key_hash"`
 }
 
@@ -36,20 +38,31 @@ type APIKeyMetadata struct {
 	LastKeyAdded time.Time `json:"last_key_added" firestore:"last_key_added"`
 }
 
+// APIKeyAuditEntry mirrors server.APIKeyAuditEntry
+type APIKeyAuditEntry struct {
+	Action    string    `json:"action" firestore:"action"`
+	KeyHash   string    `json:"key_hash" firestore:"key_hash"`
+	Actor     string    `json:"actor" firestore:"actor"`
+	Fields    []string  `json:"fields,omitempty" firestore:"fields,omitempty"`
+	Timestamp time.Time `json:"timestamp" firestore:"timestamp"`
+}
+
 // Collection names
 const (
 	apiKeysCollection     = "api_keys"
 	apiKeysMetaCollection = "api_keys_meta"
 	apiKeysMetaDocument   = "metadata"
+	apiKeyAuditCollection = "api_key_audit"
 )
 
 func main() {
 	var (
-		action = flag.String("action", "", "Action: add, update, deactivate, activate, list")
+		action = flag.String("action", "", "Action: add, update, deactivate, activate, list, recompute")
 		key    = flag.String("key", "", "API key (for update/deactivate/activate)")
 		prefix = flag.String("prefix", "ak_live", "Prefix for new key")
 		expiry = flag.String("expiry", "2025-12-31T23:59:59Z", "Expiry time")
 		source = flag.String("source", "go_script", "Source of the key")
+		scopes = flag.String("scopes", "", "Comma-separated scopes for new key (e.g. admin)")
 	)
 	flag.Parse()
 
@@ -61,15 +74,19 @@ func main() {
 		fmt.Println("  activate   - Activate an existing key")
 		fmt.Println("  deactivate - Deactivate an existing key")
 		fmt.Println("  list       - List all API keys")
+		fmt.Println("  recompute  - Recompute key counts in the metadata document")
 		fmt.Println("\nOptions:")
 		fmt.Println("  -key       - API key (required for update/activate/deactivate)")
 		fmt.Println("  -prefix    - Prefix for new key (default: ak_live)")
 		fmt.Println("  -expiry    - Expiry time in RFC3339 format (default: 2025-12-31T23:59:59Z)")
 		fmt.Println("  -source    - Source description (default: go_script)")
+		fmt.Println("  -scopes    - Comma-separated scopes for new key (e.g. admin)")
 		fmt.Println("\nExamples:")
 		fmt.Println("  go run main.go -action=add -prefix=ak_prod -source=manual")
+		fmt.Println("  go run main.go -action=add -prefix=ak_ops -scopes=admin")
 		fmt.Println("  go run main.go -action=deactivate -key=ak_live_1234567890abcdef")
 		fmt.Println("  go run main.go -action=list")
+		fmt.Println("  go run main.go -action=recompute")
 		os.Exit(1)
 	}
 
@@ -95,12 +112,12 @@ func main() {
 
 	switch *action {
 	case "add":
-		err = addAPIKey(ctx, client, *prefix, *expiry, *source)
+		err = addAPIKey(ctx, client, *prefix, *expiry, *source, parseScopes(*scopes))
 	case "update":
 		if *key == "" {
 			log.Fatal("Key is required for update action")
 		}
-		err = updateAPIKey(ctx, client, *key, map[string]interface{}{
+		err = updateAPIKey(ctx, client, *key, "update", map[string]interface{}{
 			"expiry_time": parseTime(*expiry),
 			"updated_at":  time.Now().UTC(),
 		})
@@ -108,7 +125,7 @@ func main() {
 		if *key == "" {
 			log.Fatal("Key is required for activate action")
 		}
-		err = updateAPIKey(ctx, client, *key, map[string]interface{}{
+		err = updateAPIKey(ctx, client, *key, "update", map[string]interface{}{
 			"is_active":  true,
 			"updated_at": time.Now().UTC(),
 		})
@@ -116,14 +133,16 @@ func main() {
 		if *key == "" {
 			log.Fatal("Key is required for deactivate action")
 		}
-		err = updateAPIKey(ctx, client, *key, map[string]interface{}{
+		err = updateAPIKey(ctx, client, *key, "delete", map[string]interface{}{
 			"is_active":  false,
 			"updated_at": time.Now().UTC(),
 		})
 	case "list":
 		err = listAPIKeys(ctx, client)
+	case "recompute":
+		err = recomputeMetadata(ctx, client)
 	default:
-		log.Fatal("Unknown action. Use: add, update, activate, deactivate, list")
+		log.Fatal("Unknown action. Use: add, update, activate, deactivate, list, recompute")
 	}
 
 	if err != nil {
@@ -131,7 +150,7 @@ func main() {
 	}
 }
 
-func addAPIKey(ctx context.Context, client *firestore.Client, prefix, expiry, source string) error {
+func addAPIKey(ctx context.Context, client *firestore.Client, prefix, expiry, source string, scopes []string) error {
 	newKey := APIKey{
 		Key:        generateAPIKey(prefix),
 		ExpiryTime: parseTime(expiry),
@@ -139,6 +158,7 @@ func addAPIKey(ctx context.Context, client *firestore.Client, prefix, expiry, so
 		CreatedAt:  time.Now().UTC(),
 		UpdatedAt:  time.Now().UTC(),
 		IsActive:   true,
+		Scopes:     scopes,
 	}
 
 	// Generate hash for document ID
@@ -184,15 +204,22 @@ func addAPIKey(ctx context.Context, client *firestore.Client, prefix, expiry, so
 			return fmt.Errorf("failed to update metadata: %w", err)
 		}
 
+		if err := writeAudit(client, tx, "create", newKey.KeyHash, nil); err != nil {
+			return err
+		}
+
 		fmt.Printf("✅ Added new API key: %s\n", newKey.Key)
 		fmt.Printf("   Document ID: %s\n", newKey.KeyHash[:12]+"...")
 		fmt.Printf("   Expires: %s\n", newKey.ExpiryTime.Format("2006-01-02 15:04:05 UTC"))
 		fmt.Printf("   Source: %s\n", newKey.Source)
+		if len(newKey.Scopes) > 0 {
+			fmt.Printf("   Scopes: %s\n", strings.Join(newKey.Scopes, ", "))
+		}
 		return nil
 	})
 }
 
-func updateAPIKey(ctx context.Context, client *firestore.Client, keyToUpdate string, updates map[string]interface{}) error {
+func updateAPIKey(ctx context.Context, client *firestore.Client, keyToUpdate, auditAction string, updates map[string]interface{}) error {
 	keyHash := hashAPIKey(keyToUpdate)
 	docRef := client.Collection(apiKeysCollection).Doc(keyHash)
 
@@ -208,8 +235,14 @@ func updateAPIKey(ctx context.Context, client *firestore.Client, keyToUpdate str
 		})
 	}
 
-	if _, err := docRef.Update(ctx, updateFields); err != nil {
-		return fmt.Errorf("failed to update API key: %w", err)
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Update(docRef, updateFields); err != nil {
+			return fmt.Errorf("failed to update API key: %w", err)
+		}
+		return writeAudit(client, tx, auditAction, keyHash, updates)
+	})
+	if err != nil {
+		return err
 	}
 
 	// Log what was updated
@@ -282,6 +315,9 @@ func listAPIKeys(ctx context.Context, client *firestore.Client) error {
 		fmt.Printf("   Status: %s\n", status)
 		fmt.Printf("   Expires: %s\n", key.ExpiryTime.Format("2006-01-02 15:04:05 UTC"))
 		fmt.Printf("   Source: %s\n", key.Source)
+		if len(key.Scopes) > 0 {
+			fmt.Printf("   Scopes: %s\n", strings.Join(key.Scopes, ", "))
+		}
 		fmt.Printf("   Created: %s\n", key.CreatedAt.Format("2006-01-02 15:04:05 UTC"))
 		fmt.Printf("   Updated: %s\n", key.UpdatedAt.Format("2006-01-02 15:04:05 UTC"))
 		fmt.Println()
@@ -305,6 +341,89 @@ func listAPIKeys(ctx context.Context, client *firestore.Client) error {
 	return nil
 }
 
+// recomputeMetadata rebuilds the metadata counts from the key documents,
+// correcting drift left by manual edits.
+func recomputeMetadata(ctx context.Context, client *firestore.Client) error {
+	metaRef := client.Collection(apiKeysMetaCollection).Doc(apiKeysMetaDocument)
+
+	var before, after APIKeyMetadata
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		before = APIKeyMetadata{}
+		if metaDoc, err := tx.Get(metaRef); err == nil {
+			metaDoc.DataTo(&before)
+		}
+
+		iter := tx.Documents(client.Collection(apiKeysCollection))
+		defer iter.Stop()
+
+		now := time.Now().UTC()
+		after = APIKeyMetadata{LastUpdated: now}
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to iterate API keys: %w", err)
+			}
+
+			var apiKey APIKey
+			if err := doc.DataTo(&apiKey); err != nil {
+				log.Printf("Warning: failed to parse API key document %s: %v", doc.Ref.ID, err)
+				continue
+			}
+
+			after.TotalKeys++
+			if apiKey.IsActive && now.Before(apiKey.ExpiryTime) {
+				after.ActiveKeys++
+			}
+			if apiKey.CreatedAt.After(after.LastKeyAdded) {
+				after.LastKeyAdded = apiKey.CreatedAt
+			}
+		}
+
+		return tx.Set(metaRef, after)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to recompute metadata: %w", err)
+	}
+
+	fmt.Printf("📊 Before: Total Keys: %d, Active Keys: %d, Last Key Added: %s\n",
+		before.TotalKeys, before.ActiveKeys, before.LastKeyAdded.Format("2006-01-02 15:04:05 UTC"))
+	fmt.Printf("📊 After:  Total Keys: %d, Active Keys: %d, Last Key Added: %s\n",
+		after.TotalKeys, after.ActiveKeys, after.LastKeyAdded.Format("2006-01-02 15:04:05 UTC"))
+	fmt.Println("✅ Metadata recomputed")
+	return nil
+}
+
+// writeAudit appends an api_key_audit entry attributed to the local user.
+func writeAudit(client *firestore.Client, tx *firestore.Transaction, action, keyHash string, updates map[string]interface{}) error {
+	var fields []string
+	for field := range updates {
+		if field != "updated_at" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	actor := "cli"
+	if user := os.Getenv("USER"); user != "" {
+		actor = "cli:" + user
+	}
+
+	entry := APIKeyAuditEntry{
+		Action:    action,
+		KeyHash:   keyHash,
+		Actor:     actor,
+		Fields:    fields,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := tx.Create(client.Collection(apiKeyAuditCollection).NewDoc(), entry); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
 func generateAPIKey(prefix string) string {
 	bytes := make([]byte, 16)
 	if _, err := rand.Read(bytes); err != nil {
@@ -313,6 +432,16 @@ func generateAPIKey(prefix string) string {
 	return prefix + "_" + hex.EncodeToString(bytes)
 }
 
+func parseScopes(raw string) []string {
+	var scopes []string
+	for _, part := range strings.Split(raw, ",") {
+		if scope := strings.ToLower(strings.TrimSpace(part)); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
 func parseTime(timeStr string) time.Time {
 	t, err := time.Parse(time.RFC3339, timeStr)
 	if err != nil {
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"upwork-job-api/server"
+)
+
+const jobListCollection = "job_list"
+
+// transform-diff prints which raw fields of a document the transform reads
+// and which it ignores, to spot scraped fields not yet mapped into JobRecord.
+func main() {
+	projectID := os.Getenv("FIREBASE_PROJECT_ID")
+	if projectID == "" {
+		log.Fatal("FIREBASE_PROJECT_ID is required")
+	}
+	serviceAccountPath := os.Getenv("FIREBASE_SERVICE_ACCOUNT_PATH")
+	if serviceAccountPath == "" {
+		log.Fatal("FIREBASE_SERVICE_ACCOUNT_PATH is required")
+	}
+	defaultCollection := os.Getenv("FIRESTORE_COLLECTION")
+	if defaultCollection == "" {
+		defaultCollection = "individual_jobs"
+	}
+
+	collection := flag.String("collection", defaultCollection, "collection to read (individual_jobs or job_list)")
+	docID := flag.String("id", "", "document ID to inspect (default: the first -limit documents)")
+	limit := flag.Int("limit", 1, "number of documents to inspect when -id is not set")
+	showConsumed := flag.Bool("consumed", false, "also list the consumed fields")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := firestore.NewClient(ctx, projectID, option.WithCredentialsFile(serviceAccountPath))
+	if err != nil {
+		log.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	jobList := *collection == jobListCollection
+
+	if *docID != "" {
+		doc, err := client.Collection(*collection).Doc(*docID).Get(ctx)
+		if err != nil {
+			log.Fatalf("failed to fetch document %s: %v", *docID, err)
+		}
+		report(doc, jobList, *showConsumed)
+		return
+	}
+
+	iter := client.Collection(*collection).Limit(*limit).Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Fatalf("iteration error: %v", err)
+		}
+		report(doc, jobList, *showConsumed)
+	}
+}
+
+func report(doc *firestore.DocumentSnapshot, jobList, showConsumed bool) {
+	records, usage, err := server.TraceTransformDocument(doc, jobList)
+	if err != nil {
+		log.Printf("transform error for %s: %v", doc.Ref.ID, err)
+		return
+	}
+
+	fmt.Printf("📄 %s: %d records, %d fields consumed, %d ignored\n", doc.Ref.ID, len(records), len(usage.Consumed), len(usage.Ignored))
+	if showConsumed {
+		for _, path := range usage.Consumed {
+			fmt.Printf("   ✅ %s\n", path)
+		}
+	}
+	for _, path := range usage.Ignored {
+		fmt.Printf("   ⚠️ %s\n", path)
+	}
+	fmt.Println()
+}
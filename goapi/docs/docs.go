@@ -18,21 +18,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api-keys/refresh-cache": {
-            "post": {
+        "/admin/cache/jobs": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Forces a refresh of the API keys cache from Firestore",
+                "description": "Removes cached /jobs responses whose filters overlap the given ones, using an index of cache keys to filters. Pass filter params directly (e.g. category=Web Development) or an upwork_url. Cached queries that do not constrain a given param are also cleared, since their results may include affected jobs.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-keys"
+                    "admin"
+                ],
+                "summary": "Clear cached /jobs responses by filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upwork search URL whose filters select the entries to clear",
+                        "name": "upwork_url",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Refresh API keys cache",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -40,12 +48,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -55,35 +75,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api-keys/{key}/cache": {
-            "delete": {
+        "/admin/config": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Removes a specific API key from the cache",
+                "description": "Admin only. Returns the effective non-secret configuration (collections, cache TTLs, limits, timeouts, enabled features). Credentials are never included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-keys"
-                ],
-                "summary": "Clear API key cache",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "API key to clear from cache",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Server configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.JobsResponse"
+                            "$ref": "#/definitions/server.ServerConfig"
                         }
                     },
                     "401": {
@@ -92,8 +103,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/server.JobsResponse"
                         }
@@ -101,26 +112,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/cache/clear": {
-            "delete": {
+        "/admin/maintenance": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Removes all cached responses (does not affect API key cache)",
+                "description": "Admin only. Reports whether maintenance mode is on and whether MAINTENANCE_MODE or the toggle set it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cache"
+                    "admin"
                 ],
-                "summary": "Clear all response caches",
+                "summary": "Maintenance status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.JobsResponse"
+                            "$ref": "#/definitions/server.MaintenanceStatus"
                         }
                     },
                     "401": {
@@ -129,36 +140,53 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     }
                 }
-            }
-        },
-        "/cache/stats": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns cache hit/miss ratio and performance metrics",
+                "description": "Admin only. While on, every endpoint except /health and the admin-only /admin, /cache and /api-keys routes returns 503 with error_code MAINTENANCE and a Retry-After header. The toggle is stored in Redis and reaches all instances within a few seconds; MAINTENANCE_MODE=true keeps maintenance on regardless.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cache"
+                    "admin"
+                ],
+                "summary": "Toggle maintenance mode",
+                "parameters": [
+                    {
+                        "description": "Toggle state and optional message shown to clients",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.MaintenanceRequest"
+                        }
+                    }
                 ],
-                "summary": "Get cache statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/server.MaintenanceStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
                     "401": {
@@ -167,6 +195,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -176,26 +210,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/admin/migrate/flatten": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns a 200 response when the API is up.",
+                "description": "Admin only. Returns progress of the running or most recent flatten migration.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Health check",
+                "summary": "Flatten migration status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.JobsResponse"
+                            "$ref": "#/definitions/server.MigrationStatus"
                         }
                     },
                     "401": {
@@ -203,108 +237,168 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.JobsResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/jobs": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve normalized job documents with optional filters.",
+                "description": "Admin only. Flattens sortable fields to the document root for up to ` + "`" + `limit` + "`" + ` documents in the background. Only one migration runs at a time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "jobs"
+                    "admin"
                 ],
-                "summary": "List jobs",
+                "summary": "Start flatten migration",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Number of records (1-50)",
+                        "description": "Documents to process (default 200, max 5000)",
                         "name": "limit",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/server.MigrationStatus"
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by payment verification",
-                        "name": "payment_verified",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by category slug",
-                        "name": "category",
-                        "in": "query"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by category group slug",
-                        "name": "category_group",
-                        "in": "query"
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.MigrationStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Filter by job status (open|closed or numeric code)",
-                        "name": "status",
-                        "in": "query"
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Lists API keys ordered by key hash. Keys are masked; pass ` + "`" + `next_cursor` + "`" + ` from the response as ` + "`" + `cursor` + "`" + ` to fetch the next page until it is omitted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by job type (hourly|fixed-price or numeric code)",
-                        "name": "job_type",
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum keys per page (1-500)",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by contractor tier (entry|intermediate|expert or numeric code)",
-                        "name": "contractor_tier",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by buyer country",
-                        "name": "country",
+                        "type": "boolean",
+                        "description": "Only active (true) or inactive (false) keys",
+                        "name": "is_active",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated required tags",
-                        "name": "tags",
+                        "description": "Only keys with this source",
+                        "name": "source",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.APIKeyListResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "ISO timestamp lower bound",
-                        "name": "posted_after",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "ISO timestamp upper bound",
-                        "name": "posted_before",
-                        "in": "query"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
-                    {
-                        "type": "number",
-                        "description": "Minimum fixed budget",
-                        "name": "budget_min",
-                        "in": "query"
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/audit": {
+            "get": {
+                "security": [
                     {
-                        "type": "number",
-                        "description": "Maximum fixed budget",
-                        "name": "budget_max",
-                        "in": "query"
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Returns the most recent API key create/update/delete entries, newest first. Entries carry the key hash, never the key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "API key audit log",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sort mode (posted_on_asc, posted_on_desc, last_visited_asc, last_visited_desc)",
-                        "name": "sort",
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum entries (1-500)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -312,7 +406,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/server.JobsResponse"
+                            "$ref": "#/definitions/server.APIKeyAuditResponse"
                         }
                     },
                     "400": {
@@ -327,6 +421,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/server.JobsResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -335,25 +435,993 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "server.BudgetInfo": {
-            "type": "object",
-            "properties": {
-                "currency": {
-                    "type": "string"
-                },
-                "fixed_amount": {
-                    "type": "number"
+        },
+        "/api-keys/refresh-cache": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Forces a refresh of the API keys cache from Firestore",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Refresh API keys cache",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
                 }
             }
         },
-        "server.BuyerDTO": {
-            "type": "object",
-            "properties": {
-                "active_assignments": {
-                    "type": "integer"
+        "/api-keys/{key}/cache": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Removes a specific API key from the cache",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Clear API key cache",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key to clear from cache",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cache/clear": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Removes all cached responses (does not affect API key cache)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cache"
+                ],
+                "summary": "Clear all response caches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cache/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin only. Returns cache hit/miss ratio and performance metrics. Coalescing counters (queries_executed, requests_coalesced, firestore_reads_saved) are per instance since startup.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cache"
+                ],
+                "summary": "Get cache statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a 200 response when the API is up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve normalized job documents. Filters are read from the Upwork search URL passed in ` + "`" + `upwork_url` + "`" + `; supported URL parameters (with examples):\n` + "`" + `q=python` + "`" + ` or ` + "`" + `search=(python AND automation)` + "`" + ` (aliases; conflicting values are rejected with 400), ` + "`" + `exclude=wordpress,php` + "`" + ` (drops jobs whose text contains any listed term; a value without commas is read as a search expression), ` + "`" + `limit=20` + "`" + `, ` + "`" + `offset=20` + "`" + `, ` + "`" + `payment_verified=1` + "`" + `, ` + "`" + `t=hourly` + "`" + ` (` + "`" + `job_type` + "`" + ` is an alias; sending both with different job types is rejected with 400), ` + "`" + `contractor_tier=2` + "`" + `, ` + "`" + `contract_to_hire=true` + "`" + `, ` + "`" + `has_category=true` + "`" + ` (only jobs with category data), ` + "`" + `require_title=true` + "`" + ` (drops jobs with a blank title; the default comes from REQUIRE_TITLE, off unless set), ` + "`" + `has_hourly=true` + "`" + ` / ` + "`" + `has_fixed=true` + "`" + ` (only jobs with a positive hourly rate / fixed budget, whatever their type),\n` + "`" + `duration_v3=week,month` + "`" + `, ` + "`" + `workload=part_time` + "`" + `, ` + "`" + `amount=500-2000` + "`" + `, ` + "`" + `hourly_rate=25-75` + "`" + `, ` + "`" + `min_pay=50` + "`" + ` (fixed budget OR hourly max at least 50), ` + "`" + `client_hires=1-9` + "`" + `, ` + "`" + `client_spend_tier=10k` + "`" + ` (client total spend: none, 1k, 10k or 100k+ meaning at least $1k/$10k/$100k; none keeps clients with no spend), ` + "`" + `buyer_active_within=7d` + "`" + `, ` + "`" + `client_reviews=10-` + "`" + `, ` + "`" + `company_size=1-10,1000-` + "`" + `, ` + "`" + `industry=tech-it,health-fitness` + "`" + ` (case and punctuation are ignored, so tech-it matches Tech \u0026 IT; inside upwork_url write a literal \u0026 as %26), ` + "`" + `invitations=0-2` + "`" + `, ` + "`" + `interviewing=true` + "`" + ` (clients with interviews under way, i.e. client_activity.total_invited_to_interview of at least 1; false means none, or pass a range such as 1-5; jobs without the count never match), ` + "`" + `location=United States` + "`" + ` (or regions: africa, europe, caribbean, eu, gcc, asean, latam), ` + "`" + `country_exclude=India,PK` + "`" + ` (drops jobs whose buyer or location country matches; codes, names and eu/gcc/asean/latam accepted),\n` + "`" + `timezone=America/New_York` + "`" + `, ` + "`" + `proposals=0-4` + "`" + `, ` + "`" + `previous_clients=all` + "`" + `, ` + "`" + `subcategory2_uid=531770282580668418` + "`" + `, ` + "`" + `sort=publish_time_desc,budget_desc` + "`" + ` (an unknown sort value is rejected with 400 listing the accepted ones).\nWhen the server sets SEARCH_STOPWORDS (off by default), unquoted stopwords in search terms (the, a, job, ...) are ignored; quote a phrase to require them.\nHEAD /jobs returns the same ETag and Last-Updated headers without a body, for cheap change polling.\nIf the query deadline expires after some matching jobs were found, those are returned with ` + "`" + `partial: true` + "`" + ` (and not cached).\n` + "`" + `matched_in_fetch` + "`" + ` counts fetched jobs that passed the filters before offset and limit (\"showing 20 of 137\"). It is best effort: at most 500 documents are fetched per query, so when ` + "`" + `matched_is_lower_bound` + "`" + ` is true more jobs may match.\nSort values: publish_time_asc, publish_time_desc, last_visited_asc, last_visited_desc, budget_asc, budget_desc, created_on_asc, created_on_desc, quality_asc, quality_desc, freshness_asc, freshness_desc (by the later of publish time and last visit), hot (needs FEATURES=relevance_sort; comma-separate for multi-key sorts).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "https://www.upwork.com/nx/search/jobs/?q=python\u0026hourly_rate=20-40",
+                        "description": "Full Upwork job search URL to translate into filters",
+                        "name": "upwork_url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "30s",
+                        "description": "Admin only: response cache TTL override (e.g. 30s, 5m)",
+                        "name": "cache_ttl",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Admin only: set to true to bypass the cache and add skipped counts by reason (transform_error, filtered_out, duplicate)",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "30d",
+                        "description": "Exclude jobs not visited within this window (e.g. 72h, 30d); 0 disables the server default",
+                        "name": "max_staleness",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "example": "false",
+                        "description": "Set to false to include documents missing the sort field",
+                        "name": "strict_order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "snake",
+                            "camel"
+                        ],
+                        "type": "string",
+                        "default": "snake",
+                        "example": "camel",
+                        "description": "Response key casing: snake (default) or camel",
+                        "name": "case",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to return only matching job IDs in ` + "`" + `ids` + "`" + ` (data is null)",
+                        "name": "ids_only",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true so search terms also match words sharing their stem (develop matches developer, developing); exact matching otherwise",
+                        "name": "stem",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to add display strings such as $1,200 next to budget amounts",
+                        "name": "format_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "5",
+                        "description": "Opt-in: while fewer jobs match, drop filters in this order and retry, listing them in relaxed_filters: buyer_active_within, client_reviews, company_size, invitations, interviewing, proposals, previous_clients, client_spend_tier, client_hires, industry, timezone, duration_v3, workload, contract_to_hire, contractor_tier, min_pay, hourly_rate, amount, payment_verified. Search, job type, location, category and since_id are never relaxed. Must not exceed limit",
+                        "name": "min_results",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1971966031232790280",
+                        "description": "Return only jobs published after this job, given by its numeric ID (the last one the client saw); 400 if it does not exist",
+                        "name": "since_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "example": "America/New_York",
+                        "description": "IANA time zone for emitted timestamps (posted_on, created_on, publish_time, last_visited_at); unknown zones fall back to UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to add the similar jobs listed on private job pages (flagged from_similar)",
+                        "name": "include_similar",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Fingerprint of the returned jobs; unchanged while results are unchanged"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Newest last_visited_at among the returned jobs; honors If-Modified-Since with 304"
+                            },
+                            "Last-Updated": {
+                                "type": "string",
+                                "description": "When the response was generated (RFC 3339)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve normalized job documents. Filters are read from the Upwork search URL passed in ` + "`" + `upwork_url` + "`" + `; supported URL parameters (with examples):\n` + "`" + `q=python` + "`" + ` or ` + "`" + `search=(python AND automation)` + "`" + ` (aliases; conflicting values are rejected with 400), ` + "`" + `exclude=wordpress,php` + "`" + ` (drops jobs whose text contains any listed term; a value without commas is read as a search expression), ` + "`" + `limit=20` + "`" + `, ` + "`" + `offset=20` + "`" + `, ` + "`" + `payment_verified=1` + "`" + `, ` + "`" + `t=hourly` + "`" + ` (` + "`" + `job_type` + "`" + ` is an alias; sending both with different job types is rejected with 400), ` + "`" + `contractor_tier=2` + "`" + `, ` + "`" + `contract_to_hire=true` + "`" + `, ` + "`" + `has_category=true` + "`" + ` (only jobs with category data), ` + "`" + `require_title=true` + "`" + ` (drops jobs with a blank title; the default comes from REQUIRE_TITLE, off unless set), ` + "`" + `has_hourly=true` + "`" + ` / ` + "`" + `has_fixed=true` + "`" + ` (only jobs with a positive hourly rate / fixed budget, whatever their type),\n` + "`" + `duration_v3=week,month` + "`" + `, ` + "`" + `workload=part_time` + "`" + `, ` + "`" + `amount=500-2000` + "`" + `, ` + "`" + `hourly_rate=25-75` + "`" + `, ` + "`" + `min_pay=50` + "`" + ` (fixed budget OR hourly max at least 50), ` + "`" + `client_hires=1-9` + "`" + `, ` + "`" + `client_spend_tier=10k` + "`" + ` (client total spend: none, 1k, 10k or 100k+ meaning at least $1k/$10k/$100k; none keeps clients with no spend), ` + "`" + `buyer_active_within=7d` + "`" + `, ` + "`" + `client_reviews=10-` + "`" + `, ` + "`" + `company_size=1-10,1000-` + "`" + `, ` + "`" + `industry=tech-it,health-fitness` + "`" + ` (case and punctuation are ignored, so tech-it matches Tech \u0026 IT; inside upwork_url write a literal \u0026 as %26), ` + "`" + `invitations=0-2` + "`" + `, ` + "`" + `interviewing=true` + "`" + ` (clients with interviews under way, i.e. client_activity.total_invited_to_interview of at least 1; false means none, or pass a range such as 1-5; jobs without the count never match), ` + "`" + `location=United States` + "`" + ` (or regions: africa, europe, caribbean, eu, gcc, asean, latam), ` + "`" + `country_exclude=India,PK` + "`" + ` (drops jobs whose buyer or location country matches; codes, names and eu/gcc/asean/latam accepted),\n` + "`" + `timezone=America/New_York` + "`" + `, ` + "`" + `proposals=0-4` + "`" + `, ` + "`" + `previous_clients=all` + "`" + `, ` + "`" + `subcategory2_uid=531770282580668418` + "`" + `, ` + "`" + `sort=publish_time_desc,budget_desc` + "`" + ` (an unknown sort value is rejected with 400 listing the accepted ones).\nWhen the server sets SEARCH_STOPWORDS (off by default), unquoted stopwords in search terms (the, a, job, ...) are ignored; quote a phrase to require them.\nHEAD /jobs returns the same ETag and Last-Updated headers without a body, for cheap change polling.\nIf the query deadline expires after some matching jobs were found, those are returned with ` + "`" + `partial: true` + "`" + ` (and not cached).\n` + "`" + `matched_in_fetch` + "`" + ` counts fetched jobs that passed the filters before offset and limit (\"showing 20 of 137\"). It is best effort: at most 500 documents are fetched per query, so when ` + "`" + `matched_is_lower_bound` + "`" + ` is true more jobs may match.\nSort values: publish_time_asc, publish_time_desc, last_visited_asc, last_visited_desc, budget_asc, budget_desc, created_on_asc, created_on_desc, quality_asc, quality_desc, freshness_asc, freshness_desc (by the later of publish time and last visit), hot (needs FEATURES=relevance_sort; comma-separate for multi-key sorts).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "https://www.upwork.com/nx/search/jobs/?q=python\u0026hourly_rate=20-40",
+                        "description": "Full Upwork job search URL to translate into filters",
+                        "name": "upwork_url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "30s",
+                        "description": "Admin only: response cache TTL override (e.g. 30s, 5m)",
+                        "name": "cache_ttl",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Admin only: set to true to bypass the cache and add skipped counts by reason (transform_error, filtered_out, duplicate)",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "30d",
+                        "description": "Exclude jobs not visited within this window (e.g. 72h, 30d); 0 disables the server default",
+                        "name": "max_staleness",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "example": "false",
+                        "description": "Set to false to include documents missing the sort field",
+                        "name": "strict_order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "snake",
+                            "camel"
+                        ],
+                        "type": "string",
+                        "default": "snake",
+                        "example": "camel",
+                        "description": "Response key casing: snake (default) or camel",
+                        "name": "case",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to return only matching job IDs in ` + "`" + `ids` + "`" + ` (data is null)",
+                        "name": "ids_only",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true so search terms also match words sharing their stem (develop matches developer, developing); exact matching otherwise",
+                        "name": "stem",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to add display strings such as $1,200 next to budget amounts",
+                        "name": "format_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "5",
+                        "description": "Opt-in: while fewer jobs match, drop filters in this order and retry, listing them in relaxed_filters: buyer_active_within, client_reviews, company_size, invitations, interviewing, proposals, previous_clients, client_spend_tier, client_hires, industry, timezone, duration_v3, workload, contract_to_hire, contractor_tier, min_pay, hourly_rate, amount, payment_verified. Search, job type, location, category and since_id are never relaxed. Must not exceed limit",
+                        "name": "min_results",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1971966031232790280",
+                        "description": "Return only jobs published after this job, given by its numeric ID (the last one the client saw); 400 if it does not exist",
+                        "name": "since_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "example": "America/New_York",
+                        "description": "IANA time zone for emitted timestamps (posted_on, created_on, publish_time, last_visited_at); unknown zones fall back to UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "example": "true",
+                        "description": "Set to true to add the similar jobs listed on private job pages (flagged from_similar)",
+                        "name": "include_similar",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Fingerprint of the returned jobs; unchanged while results are unchanged"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Newest last_visited_at among the returned jobs; honors If-Modified-Since with 304"
+                            },
+                            "Last-Updated": {
+                                "type": "string",
+                                "description": "When the response was generated (RFC 3339)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch up to 50 jobs by ID. Duplicate IDs are ignored; IDs without a matching document are listed in ` + "`" + `missing` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Batch job lookup",
+                "parameters": [
+                    {
+                        "description": "Job IDs to fetch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch one job by ID. Admin-scoped keys may pass ` + "`" + `include_raw=true` + "`" + ` to attach the original Firestore document for diagnosing transformation gaps.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "default": "false",
+                        "description": "Admin only: attach the raw Firestore document",
+                        "name": "include_raw",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/openapi.json": {
+            "get": {
+                "description": "Returns the generated Swagger 2.0 spec as JSON. Does not require an API key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docs"
+                ],
+                "summary": "OpenAPI spec",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/skills/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Distinct skill labels from recent jobs starting with ` + "`" + `q` + "`" + `, ranked by how many sampled jobs carry them. The sample is refreshed periodically, so responses may be cached by clients.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Suggest skills",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "py",
+                        "description": "Skill prefix (case-insensitive)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum suggestions (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.SkillSuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upwork-url/parse": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs the /jobs URL parser on ` + "`" + `url` + "`" + ` and returns the derived query parameters and the resolved filters (with defaults such as limit and sort applied) without querying jobs. Invalid URLs and filter values return 400 with the reason in ` + "`" + `message` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Preview Upwork URL filters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "https://www.upwork.com/nx/search/jobs/?q=python\u0026hourly_rate=20-40",
+                        "description": "Full Upwork job search URL",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.UpworkURLParseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.JobsResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "server.APIKeyAuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key_hash": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.APIKeyAuditResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.APIKeyAuditEntry"
+                    }
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.APIKeySummary"
+                    }
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to fetch the next page; empty on the last",
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.APIKeySummary": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expiry_time": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_hash": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.BudgetInfo": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "fixed_amount": {
+                    "type": "number"
+                },
+                "fixed_amount_display": {
+                    "description": "FixedAmountDisplay is set only when format_currency=true, e.g. \"$1,200\"",
+                    "type": "string"
+                },
+                "secondary": {
+                    "description": "Secondary holds the other source's budget when the document's budget\nand amount maps disagree on currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.SecondaryBudget"
+                        }
+                    ]
+                }
+            }
+        },
+        "server.BuyerDTO": {
+            "type": "object",
+            "properties": {
+                "active_assignments": {
+                    "type": "integer"
                 },
                 "city": {
                     "type": "string"
@@ -361,6 +1429,9 @@ const docTemplate = `{
                 "company_industry": {
                     "type": "string"
                 },
+                "company_name": {
+                    "type": "string"
+                },
                 "company_size": {
                     "type": "integer"
                 },
@@ -376,65 +1447,228 @@ const docTemplate = `{
                 "open_jobs_count": {
                     "type": "integer"
                 },
-                "payment_verified": {
+                "payment_verified": {
+                    "type": "boolean"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "total_assignments": {
+                    "type": "integer"
+                },
+                "total_hours": {
+                    "type": "number"
+                },
+                "total_jobs_with_hires": {
+                    "type": "integer"
+                },
+                "total_spent": {
+                    "type": "number"
+                }
+            }
+        },
+        "server.CategoryInfo": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "group_slug": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.ClientActivity": {
+            "type": "object",
+            "properties": {
+                "invitations_sent": {
+                    "type": "integer"
+                },
+                "last_buyer_activity": {
+                    "type": "string"
+                },
+                "total_applicants": {
+                    "type": "integer"
+                },
+                "total_hired": {
+                    "type": "integer"
+                },
+                "total_invited_to_interview": {
+                    "description": "interviews under way signal active hiring",
+                    "type": "integer"
+                },
+                "unanswered_invites": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.ConfigCacheTTLs": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "api_keys_meta": {
+                    "type": "string"
+                },
+                "jobs": {
+                    "type": "string"
+                },
+                "max_override": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.ConfigCollections": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "string"
+                },
+                "api_keys_meta": {
+                    "type": "string"
+                },
+                "jobs": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.ConfigFeatures": {
+            "type": "object",
+            "properties": {
+                "emulator": {
+                    "type": "boolean"
+                },
+                "firestore_projection": {
+                    "type": "boolean"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "jobs_cache": {
+                    "type": "boolean"
+                },
+                "read_replica": {
+                    "type": "boolean"
+                },
+                "redis": {
+                    "type": "boolean"
+                },
+                "require_title": {
                     "type": "boolean"
+                }
+            }
+        },
+        "server.ConfigLimits": {
+            "type": "object",
+            "properties": {
+                "default_limit": {
+                    "type": "integer"
                 },
-                "score": {
-                    "type": "number"
+                "max_batch_ids": {
+                    "type": "integer"
                 },
-                "timezone": {
-                    "type": "string"
+                "max_body_bytes": {
+                    "type": "integer"
                 },
-                "total_assignments": {
+                "max_limit": {
                     "type": "integer"
                 },
-                "total_hours": {
-                    "type": "number"
+                "max_param_length": {
+                    "type": "integer"
                 },
-                "total_jobs_with_hires": {
+                "max_query_length": {
                     "type": "integer"
                 },
-                "total_spent": {
-                    "type": "number"
+                "max_staleness": {
+                    "type": "string"
                 }
             }
         },
-        "server.CategoryInfo": {
+        "server.ConfigSearch": {
             "type": "object",
             "properties": {
-                "group": {
+                "field_weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "stopwords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "server.ConfigSorting": {
+            "type": "object",
+            "properties": {
+                "budget_currency_source": {
                     "type": "string"
                 },
-                "group_slug": {
+                "future_publish_action": {
                     "type": "string"
                 },
-                "name": {
+                "future_publish_window": {
                     "type": "string"
                 },
-                "slug": {
+                "hot_half_life": {
                     "type": "string"
+                },
+                "privacy_status_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quality_weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
                 }
             }
         },
-        "server.ClientActivity": {
+        "server.ConfigTimeouts": {
             "type": "object",
             "properties": {
-                "invitations_sent": {
-                    "type": "integer"
-                },
-                "last_buyer_activity": {
+                "replica": {
                     "type": "string"
                 },
-                "total_applicants": {
+                "request": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.FlattenStats": {
+            "type": "object",
+            "properties": {
+                "errors": {
                     "type": "integer"
                 },
-                "total_hired": {
+                "processed": {
                     "type": "integer"
                 },
-                "total_invited_to_interview": {
+                "skipped": {
                     "type": "integer"
                 },
-                "unanswered_invites": {
+                "updated": {
                     "type": "integer"
                 }
             }
@@ -486,6 +1720,9 @@ const docTemplate = `{
                 "engagement": {
                     "type": "string"
                 },
+                "from_similar": {
+                    "type": "boolean"
+                },
                 "hide_budget": {
                     "type": "boolean"
                 },
@@ -528,6 +1765,9 @@ const docTemplate = `{
                 "privacy_reason": {
                     "type": "string"
                 },
+                "privacy_status": {
+                    "type": "string"
+                },
                 "proposals_tier": {
                     "type": "string"
                 },
@@ -540,6 +1780,9 @@ const docTemplate = `{
                 "qualifications": {
                     "$ref": "#/definitions/server.JobQualifications"
                 },
+                "quality_score": {
+                    "type": "number"
+                },
                 "recno": {
                     "type": "integer"
                 },
@@ -615,6 +1858,70 @@ const docTemplate = `{
                 }
             }
         },
+        "server.JobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/server.JobDTO"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "raw": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.JobsBatchRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "server.JobsBatchResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/server.JobDTO"
+                    }
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "server.JobsResponse": {
             "type": "object",
             "properties": {
@@ -627,14 +1934,224 @@ const docTemplate = `{
                         "$ref": "#/definitions/server.JobDTO"
                     }
                 },
+                "error_code": {
+                    "type": "string"
+                },
+                "ids": {
+                    "description": "IDs replaces Data when ids_only=true",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_modified": {
+                    "description": "LastModified is the newest last_visited_at among the returned jobs",
+                    "type": "string"
+                },
                 "last_updated": {
                     "type": "string"
                 },
+                "matched_in_fetch": {
+                    "description": "MatchedInFetch counts fetched documents that passed the filters,\nbefore offset and limit. Fetches stop at 500 documents, so when\nMatchedLowerBound is set more jobs may match than reported.",
+                    "type": "integer"
+                },
+                "matched_is_lower_bound": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "partial": {
+                    "description": "Partial is set when the query deadline cut the scan short",
+                    "type": "boolean"
+                },
+                "relaxed_filters": {
+                    "description": "RelaxedFilters lists filters dropped to reach min_results, in order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped": {
+                    "description": "Skipped counts dropped documents by reason for admin debug=true requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.SkipSummary"
+                        }
+                    ]
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.MaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.MaintenanceStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
                 "message": {
                     "type": "string"
                 },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.MigrationStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/server.FlattenStats"
+                }
+            }
+        },
+        "server.SecondaryBudget": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "fixed_amount": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.ServerConfig": {
+            "type": "object",
+            "properties": {
+                "cache_ttls": {
+                    "$ref": "#/definitions/server.ConfigCacheTTLs"
+                },
+                "collections": {
+                    "$ref": "#/definitions/server.ConfigCollections"
+                },
+                "features": {
+                    "$ref": "#/definitions/server.ConfigFeatures"
+                },
+                "limits": {
+                    "$ref": "#/definitions/server.ConfigLimits"
+                },
+                "search": {
+                    "$ref": "#/definitions/server.ConfigSearch"
+                },
+                "sorting": {
+                    "$ref": "#/definitions/server.ConfigSorting"
+                },
+                "timeouts": {
+                    "$ref": "#/definitions/server.ConfigTimeouts"
+                }
+            }
+        },
+        "server.SkillSuggestResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.SkillSuggestion"
+                    }
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "sampled_at": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.SkillSuggestion": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "skill": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.SkipSummary": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "type": "integer"
+                },
+                "filtered_out": {
+                    "type": "integer"
+                },
+                "transform_error": {
+                    "type": "integer"
+                }
+            }
+        },
+        "server.UpworkURLParseResponse": {
+            "type": "object",
+            "properties": {
+                "filters": {
+                    "description": "Filters are the resolved filter options, defaults included",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Params are the query parameters derived from the URL",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "success": {
                     "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
@@ -47,10 +47,15 @@ func main() {
 	log.Printf("📡 Gin server listening on port %s", port)
 	log.Printf("Endpoints:")
 	log.Printf("  GET    /jobs                      - Firestore-filtered jobs (requires X-API-KEY)")
+	log.Printf("  GET    /jobs/{id}                 - Single job; include_raw=true for admin keys (requires X-API-KEY)")
+	log.Printf("  GET    /skills/suggest?q=py       - Skill autocomplete from recent jobs (requires X-API-KEY)")
 	log.Printf("  GET    /health                    - Health check (requires X-API-KEY)")
 	log.Printf("  POST   /api-keys/refresh-cache    - Refresh API keys cache (requires X-API-KEY)")
 	log.Printf("  DELETE /api-keys/{key}/cache      - Clear specific API key cache (requires X-API-KEY)")
+	log.Printf("  GET    /api-keys/audit            - Recent API key changes (admin scope)")
+	log.Printf("  GET    /admin/config              - Effective non-secret configuration (admin scope)")
 	log.Printf("  GET    /swagger/*                 - API documentation")
+	log.Printf("  GET    /openapi.json              - OpenAPI (Swagger 2.0) spec for codegen")
 
 	if err := router.Run(":" + port); err != nil {
 		log.Fatalf("server failed: %v", err)
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServerConfig is the effective non-secret configuration of a running server.
+// Credentials, the legacy API key and service account paths are never included.
+type ServerConfig struct {
+	Collections ConfigCollections `json:"collections"`
+	CacheTTLs   ConfigCacheTTLs   `json:"cache_ttls"`
+	Limits      ConfigLimits      `json:"limits"`
+	Timeouts    ConfigTimeouts    `json:"timeouts"`
+	Features    ConfigFeatures    `json:"features"`
+	Sorting     ConfigSorting     `json:"sorting"`
+	Search      ConfigSearch      `json:"search"`
+}
+
+type ConfigCollections struct {
+	Jobs    string `json:"jobs"`
+	APIKeys string `json:"api_keys"`
+	KeyMeta string `json:"api_keys_meta"`
+}
+
+type ConfigCacheTTLs struct {
+	Jobs        string `json:"jobs"`
+	MaxOverride string `json:"max_override"`
+	APIKey      string `json:"api_key"`
+	APIKeysMeta string `json:"api_keys_meta"`
+}
+
+type ConfigLimits struct {
+	DefaultLimit   int    `json:"default_limit"`
+	MaxLimit       int    `json:"max_limit"`
+	MaxBatchIDs    int    `json:"max_batch_ids"`
+	MaxQueryLength int    `json:"max_query_length"`
+	MaxParamLength int    `json:"max_param_length"`
+	MaxBodyBytes   int64  `json:"max_body_bytes"`
+	MaxStaleness   string `json:"max_staleness"`
+}
+
+type ConfigTimeouts struct {
+	Request string `json:"request"`
+	Replica string `json:"replica"`
+}
+
+type ConfigFeatures struct {
+	FirestoreProjection bool     `json:"firestore_projection"`
+	ReadReplica         bool     `json:"read_replica"`
+	Redis               bool     `json:"redis"`
+	JobsCache           bool     `json:"jobs_cache"`
+	Emulator            bool     `json:"emulator"`
+	RequireTitle        bool     `json:"require_title"`
+	Flags               []string `json:"flags"`
+}
+
+type ConfigSorting struct {
+	HotHalfLife          string             `json:"hot_half_life"`
+	QualityWeights       map[string]float64 `json:"quality_weights"`
+	PrivacyStatusCodes   []int              `json:"privacy_status_codes"`
+	BudgetCurrencySource string             `json:"budget_currency_source"`
+	FuturePublishWindow  string             `json:"future_publish_window"`
+	FuturePublishAction  string             `json:"future_publish_action"`
+}
+
+type ConfigSearch struct {
+	Stopwords    []string           `json:"stopwords"`
+	FieldWeights map[string]float64 `json:"field_weights"`
+}
+
+// effectiveConfig builds the config snapshot from the Server fields.
+func (s *Server) effectiveConfig() ServerConfig {
+	maxStaleness := "off"
+	if s.maxStaleness > 0 {
+		maxStaleness = s.maxStaleness.String()
+	}
+
+	codes := make([]int, 0, len(privacyStatusCodes))
+	for code := range privacyStatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	return ServerConfig{
+		Collections: ConfigCollections{
+			Jobs:    s.collectionName,
+			APIKeys: apiKeysCollection,
+			KeyMeta: apiKeysMetaCollection,
+		},
+		CacheTTLs: ConfigCacheTTLs{
+			Jobs:        jobsCacheTTL.String(),
+			MaxOverride: maxCacheTTLOverride.String(),
+			APIKey:      apiKeyCacheTTL.String(),
+			APIKeysMeta: apiKeysMetaCacheTTL.String(),
+		},
+		Limits: ConfigLimits{
+			DefaultLimit:   defaultLimit,
+			MaxLimit:       maxLimit,
+			MaxBatchIDs:    maxBatchIDs,
+			MaxQueryLength: s.maxQueryLength,
+			MaxParamLength: s.maxParamLength,
+			MaxBodyBytes:   s.maxBodyBytes,
+			MaxStaleness:   maxStaleness,
+		},
+		Timeouts: ConfigTimeouts{
+			Request: requestTimeout.String(),
+			Replica: replicaQueryTimeout.String(),
+		},
+		Features: ConfigFeatures{
+			FirestoreProjection: s.projectFields,
+			ReadReplica:         s.replicaClient != nil,
+			Redis:               s.redisClient != nil,
+			JobsCache:           s.redisClient != nil && !s.jobsCacheDisabled,
+			Emulator:            os.Getenv("FIRESTORE_EMULATOR_HOST") != "",
+			RequireTitle:        requireTitleDefault,
+			Flags:               s.features.Names(),
+		},
+		Sorting: ConfigSorting{
+			HotHalfLife:          hotHalfLife.String(),
+			QualityWeights:       qualityWeights,
+			PrivacyStatusCodes:   codes,
+			BudgetCurrencySource: budgetCurrencySource,
+			FuturePublishWindow:  futurePublishWindow.String(),
+			FuturePublishAction:  futurePublishAction,
+		},
+		Search: ConfigSearch{
+			Stopwords:    stopwordList(),
+			FieldWeights: searchFieldWeights,
+		},
+	}
+}
+
+// handleAdminConfig returns the effective non-secret configuration.
+// @Summary Server configuration
+// @Description Admin only. Returns the effective non-secret configuration (collections, cache TTLs, limits, timeouts, enabled features). Credentials are never included.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ServerConfig
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /admin/config [get]
+func (s *Server) handleAdminConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, s.effectiveConfig())
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// apiKeyAuditCollection holds one append-only document per key mutation
+	apiKeyAuditCollection = "api_key_audit"
+
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// Audit actions recorded for API key mutations
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// APIKeyAuditEntry records who changed which key, how and when. Only the key
+// hash is stored, never the key itself.
+type APIKeyAuditEntry struct {
+	Action    string    `json:"action" firestore:"action"`
+	KeyHash   string    `json:"key_hash" firestore:"key_hash"`
+	Actor     string    `json:"actor" firestore:"actor"`
+	Fields    []string  `json:"fields,omitempty" firestore:"fields,omitempty"`
+	Timestamp time.Time `json:"timestamp" firestore:"timestamp"`
+}
+
+// APIKeyAuditResponse is returned by GET /api-keys/audit.
+type APIKeyAuditResponse struct {
+	Success     bool               `json:"success"`
+	Data        []APIKeyAuditEntry `json:"data"`
+	Count       int                `json:"count"`
+	LastUpdated string             `json:"last_updated"`
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor names the operator responsible for key mutations made with ctx.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func auditActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(auditActorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return "unknown"
+}
+
+// newAuditEntry builds an entry for a mutation of keyHash. Updated field
+// names are sorted for stable output; updated_at is implied and left out.
+func newAuditEntry(ctx context.Context, action, keyHash string, updates map[string]interface{}) APIKeyAuditEntry {
+	var fields []string
+	for field := range updates {
+		if field != "updated_at" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return APIKeyAuditEntry{
+		Action:    action,
+		KeyHash:   keyHash,
+		Actor:     auditActor(ctx),
+		Fields:    fields,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// writeAudit appends entry within tx. Create fails on an existing document,
+// so entries are never overwritten.
+func (s *APIKeyService) writeAudit(tx *firestore.Transaction, entry APIKeyAuditEntry) error {
+	ref := s.firestoreClient.Collection(apiKeyAuditCollection).NewDoc()
+	if err := tx.Create(ref, entry); err != nil {
+		return fmt.Errorf("failed to write API key audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns the most recent audit entries, newest first.
+func (s *APIKeyService) ListAuditEntries(ctx context.Context, limit int) ([]APIKeyAuditEntry, error) {
+	iter := s.firestoreClient.Collection(apiKeyAuditCollection).
+		OrderBy("timestamp", firestore.Desc).
+		Limit(limit).
+		Documents(ctx)
+	defer iter.Stop()
+
+	entries := make([]APIKeyAuditEntry, 0, limit)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read API key audit log: %w", err)
+		}
+		var entry APIKeyAuditEntry
+		if err := doc.DataTo(&entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// handleAPIKeyAudit returns recent API key audit entries.
+// @Summary API key audit log
+// @Description Admin only. Returns the most recent API key create/update/delete entries, newest first. Entries carry the key hash, never the key.
+// @Tags api-keys
+// @Produce json
+// @Param limit query int false "Maximum entries (1-500)" default(50)
+// @Success 200 {object} APIKeyAuditResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /api-keys/audit [get]
+func (s *Server) handleAPIKeyAudit(c *gin.Context) {
+	limit := defaultAuditLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAuditLimit {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	entries, err := s.apiKeyService.ListAuditEntries(c.Request.Context(), limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, APIKeyAuditResponse{
+		Success:     true,
+		Data:        entries,
+		Count:       len(entries),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	ctx := WithAuditActor(context.Background(), "ops@example.com")
+	entry := newAuditEntry(ctx, AuditActionUpdate, "abc123", map[string]interface{}{
+		"is_active":   false,
+		"updated_at":  "now",
+		"expiry_time": "later",
+	})
+
+	if entry.Action != AuditActionUpdate || entry.KeyHash != "abc123" || entry.Actor != "ops@example.com" {
+		t.Fatalf("unexpected audit entry: %+v", entry)
+	}
+	if !reflect.DeepEqual(entry.Fields, []string{"expiry_time", "is_active"}) {
+		t.Fatalf("unexpected audit fields: %v", entry.Fields)
+	}
+	if entry.Timestamp.IsZero() {
+		t.Fatalf("expected a timestamp")
+	}
+
+	if actor := newAuditEntry(context.Background(), AuditActionCreate, "abc123", nil).Actor; actor != "unknown" {
+		t.Fatalf("expected unknown actor without WithAuditActor, got %q", actor)
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAPIKeyListLimit = 50
+	maxAPIKeyListLimit     = 500
+)
+
+// APIKeySummary describes an API key without exposing it; Key is masked the
+// same way as in logs.
+type APIKeySummary struct {
+	KeyHash    string    `json:"key_hash"`
+	Key        string    `json:"key"`
+	Source     string    `json:"source"`
+	IsActive   bool      `json:"is_active"`
+	Scopes     []string  `json:"scopes,omitempty"`
+	ExpiryTime time.Time `json:"expiry_time"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// APIKeyListResponse is returned by GET /api-keys.
+type APIKeyListResponse struct {
+	Success bool            `json:"success"`
+	Data    []APIKeySummary `json:"data"`
+	Count   int             `json:"count"`
+	// NextCursor is passed as cursor to fetch the next page; empty on the last
+	NextCursor  string `json:"next_cursor,omitempty"`
+	LastUpdated string `json:"last_updated"`
+}
+
+func newAPIKeySummary(key APIKey) APIKeySummary {
+	return APIKeySummary{
+		KeyHash:    key.GetDocumentID(),
+		Key:        SanitizeAPIKeyForLog(key.Key),
+		Source:     key.Source,
+		IsActive:   key.IsActive,
+		Scopes:     key.Scopes,
+		ExpiryTime: key.ExpiryTime,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+	}
+}
+
+// parseAPIKeyListFilter reads the GET /api-keys query parameters.
+func parseAPIKeyListFilter(c *gin.Context) (APIKeyQueryFilter, error) {
+	filter := APIKeyQueryFilter{
+		Limit:      defaultAPIKeyListLimit,
+		Source:     strings.TrimSpace(c.Query("source")),
+		StartAfter: strings.TrimSpace(c.Query("cursor")),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAPIKeyListLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxAPIKeyListLimit)
+		}
+		filter.Limit = parsed
+	}
+	if raw := c.Query("is_active"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return filter, fmt.Errorf("is_active must be true or false")
+		}
+		filter.IsActive = &parsed
+	}
+	return filter, nil
+}
+
+// handleListAPIKeys pages through API keys by document ID.
+// @Summary List API keys
+// @Description Admin only. Lists API keys ordered by key hash. Keys are masked; pass `next_cursor` from the response as `cursor` to fetch the next page until it is omitted.
+// @Tags api-keys
+// @Produce json
+// @Param limit query int false "Maximum keys per page (1-500)" default(50)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param is_active query bool false "Only active (true) or inactive (false) keys"
+// @Param source query string false "Only keys with this source"
+// @Success 200 {object} APIKeyListResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /api-keys [get]
+func (s *Server) handleListAPIKeys(c *gin.Context) {
+	filter, err := parseAPIKeyListFilter(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	keys, nextCursor, err := s.apiKeyService.ListAPIKeys(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	summaries := make([]APIKeySummary, 0, len(keys))
+	for _, key := range keys {
+		summaries = append(summaries, newAPIKeySummary(key))
+	}
+
+	c.JSON(http.StatusOK, APIKeyListResponse{
+		Success:     true,
+		Data:        summaries,
+		Count:       len(summaries),
+		NextCursor:  nextCursor,
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
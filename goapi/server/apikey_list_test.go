@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseAPIKeyListFilter(t *testing.T) {
+	parse := func(query string) (APIKeyQueryFilter, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api-keys?"+query, nil)
+		return parseAPIKeyListFilter(c)
+	}
+
+	filter, err := parse("")
+	if err != nil || filter.Limit != defaultAPIKeyListLimit || filter.StartAfter != "" || filter.IsActive != nil {
+		t.Fatalf("unexpected default filter: %+v (%v)", filter, err)
+	}
+
+	filter, err = parse("limit=2&cursor=abc123&is_active=false&source=stripe")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.Limit != 2 || filter.StartAfter != "abc123" || filter.IsActive == nil || *filter.IsActive || filter.Source != "stripe" {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+
+	for _, query := range []string{"limit=0", "limit=501", "limit=ten", "is_active=maybe"} {
+		if _, err := parse(query); err == nil {
+			t.Fatalf("%s: expected an error", query)
+		}
+	}
+}
+
+func TestNewAPIKeySummaryMasksKey(t *testing.T) {
+	key := APIKey{Key: "uwk_live_0123456789abcdef", Source: "manual", IsActive: true}
+	summary := newAPIKeySummary(key)
+	if strings.Contains(summary.Key, "0123456789") || summary.Key != SanitizeAPIKeyForLog(key.Key) {
+		t.Fatalf("expected a masked key, got %q", summary.Key)
+	}
+	if summary.KeyHash != HashAPIKey(key.Key) {
+		t.Fatalf("expected the key hash as document ID, got %q", summary.KeyHash)
+	}
+}
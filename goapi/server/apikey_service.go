@@ -126,10 +126,12 @@ func (s *APIKeyService) fetchAPIKeyByHash(ctx context.Context, keyHash string) (
 	return &apiKey, nil
 }
 
-// AddAPIKey adds a new API key to Firestore and updates metadata
+// AddAPIKey adds a new API key to Firestore, updates metadata and records an
+// audit entry for the actor in ctx (see WithAuditActor)
 func (s *APIKeyService) AddAPIKey(ctx context.Context, apiKey *APIKey) error {
 	// Generate hash for document ID
 	apiKey.GenerateKeyHash()
+	audit := newAuditEntry(ctx, AuditActionCreate, apiKey.KeyHash, nil)
 
 	// Use transaction to ensure consistency
 	return s.firestoreClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
@@ -168,14 +170,23 @@ func (s *APIKeyService) AddAPIKey(ctx context.Context, apiKey *APIKey) error {
 			metadata.LastKeyAdded = time.Now().UTC()
 		}
 
-		return tx.Set(metaRef, metadata)
+		if err := tx.Set(metaRef, metadata); err != nil {
+			return err
+		}
+		return s.writeAudit(tx, audit)
 	})
 }
 
-// UpdateAPIKey updates an existing API key
+// UpdateAPIKey updates an existing API key and records an audit entry
 func (s *APIKeyService) UpdateAPIKey(ctx context.Context, key string, updates map[string]interface{}) error {
+	return s.mutateAPIKey(ctx, key, updates, AuditActionUpdate)
+}
+
+// mutateAPIKey applies updates and appends the audit entry in one transaction
+func (s *APIKeyService) mutateAPIKey(ctx context.Context, key string, updates map[string]interface{}, action string) error {
 	keyHash := HashAPIKey(key)
 	docRef := s.firestoreClient.Collection(apiKeysCollection).Doc(keyHash)
+	audit := newAuditEntry(ctx, action, keyHash, updates)
 
 	// Add updated_at timestamp
 	updates["updated_at"] = time.Now().UTC()
@@ -189,8 +200,14 @@ func (s *APIKeyService) UpdateAPIKey(ctx context.Context, key string, updates ma
 		})
 	}
 
-	if _, err := docRef.Update(ctx, updateFields); err != nil {
-		return fmt.Errorf("failed to update API key: %w", err)
+	err := s.firestoreClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Update(docRef, updateFields); err != nil {
+			return fmt.Errorf("failed to update API key: %w", err)
+		}
+		return s.writeAudit(tx, audit)
+	})
+	if err != nil {
+		return err
 	}
 
 	// Clear cache for this key
@@ -203,16 +220,25 @@ func (s *APIKeyService) UpdateAPIKey(ctx context.Context, key string, updates ma
 
 // DeleteAPIKey removes an API key (soft delete by setting inactive)
 func (s *APIKeyService) DeleteAPIKey(ctx context.Context, key string) error {
-	return s.UpdateAPIKey(ctx, key, map[string]interface{}{
+	return s.mutateAPIKey(ctx, key, map[string]interface{}{
 		"is_active": false,
-	})
+	}, AuditActionDelete)
 }
 
-// ListAPIKeys returns a list of API keys with optional filtering
-func (s *APIKeyService) ListAPIKeys(ctx context.Context, filter APIKeyQueryFilter) ([]APIKey, error) {
+// ListAPIKeys returns a list of API keys with optional filtering. When a
+// limit fills the page and no expiry range is set, it also returns the
+// document ID to pass as StartAfter for the next page.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, filter APIKeyQueryFilter) ([]APIKey, string, error) {
 	collection := s.firestoreClient.Collection(apiKeysCollection)
 	query := collection.Query
 
+	// An expiry range orders results by expiry_time first, so document ID
+	// cursors only work without one
+	hasExpiryRange := filter.ExpiryFrom != nil || filter.ExpiryTo != nil
+	if filter.StartAfter != "" && hasExpiryRange {
+		return nil, "", fmt.Errorf("cursor cannot be combined with an expiry range")
+	}
+
 	// Apply filters
 	if filter.IsActive != nil {
 		query = query.Where("is_active", "==", *filter.IsActive)
@@ -230,6 +256,12 @@ func (s *APIKeyService) ListAPIKeys(ctx context.Context, filter APIKeyQueryFilte
 		query = query.Where("expiry_time", "<=", *filter.ExpiryTo)
 	}
 
+	// Without a cursor Firestore already returns documents in ID order, so the
+	// first page lines up with the cursor pages
+	if filter.StartAfter != "" {
+		query = query.OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(filter.StartAfter)
+	}
+
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
@@ -239,14 +271,18 @@ func (s *APIKeyService) ListAPIKeys(ctx context.Context, filter APIKeyQueryFilte
 	defer iter.Stop()
 
 	var apiKeys []APIKey
+	var lastID string
+	read := 0
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+			return nil, "", fmt.Errorf("failed to iterate API keys: %w", err)
 		}
+		lastID = doc.Ref.ID
+		read++
 
 		var apiKey APIKey
 		if err := doc.DataTo(&apiKey); err != nil {
@@ -257,7 +293,13 @@ func (s *APIKeyService) ListAPIKeys(ctx context.Context, filter APIKeyQueryFilte
 		apiKeys = append(apiKeys, apiKey)
 	}
 
-	return apiKeys, nil
+	// Counted on documents read, so unparseable ones do not end paging early
+	nextCursor := ""
+	if filter.Limit > 0 && read == filter.Limit && !hasExpiryRange {
+		nextCursor = lastID
+	}
+
+	return apiKeys, nextCursor, nil
 }
 
 // RefreshCache clears all API key caches
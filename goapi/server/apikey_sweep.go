@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// defaultExpirySweepInterval is how often expired keys are deactivated
+const defaultExpirySweepInterval = time.Hour
+
+// AuditActionExpire marks keys deactivated by the expiry sweep
+const AuditActionExpire = "expire"
+
+// expirySweepActor attributes sweep changes in the audit log
+const expirySweepActor = "system:expiry-sweep"
+
+// SweepExpiredKeys deactivates keys whose expiry has passed but are still
+// active, decrementing the metadata ActiveKeys count for each. It returns the
+// number of keys deactivated.
+func (s *APIKeyService) SweepExpiredKeys(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	// Range-only query so no composite index is needed; activity is checked
+	// per key inside the transaction
+	iter := s.firestoreClient.Collection(apiKeysCollection).Where("expiry_time", "<", now).Documents(ctx)
+	defer iter.Stop()
+
+	var candidates []*firestore.DocumentRef
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to query expired API keys: %w", err)
+		}
+		if active, err := doc.DataAt("is_active"); err == nil && active == true {
+			candidates = append(candidates, doc.Ref)
+		}
+	}
+
+	ctx = WithAuditActor(ctx, expirySweepActor)
+	deactivated := 0
+	for _, ref := range candidates {
+		changed, err := s.expireKey(ctx, ref, now)
+		if err != nil {
+			return deactivated, err
+		}
+		if changed {
+			deactivated++
+			s.redisClient.Delete(ctx, apiKeyCachePrefix+ref.ID)
+		}
+	}
+	return deactivated, nil
+}
+
+// expireKey deactivates one key and corrects metadata in a transaction,
+// skipping keys that were renewed or deactivated since the query ran.
+func (s *APIKeyService) expireKey(ctx context.Context, ref *firestore.DocumentRef, now time.Time) (bool, error) {
+	changed := false
+	err := s.firestoreClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		changed = false
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return fmt.Errorf("failed to read API key %s: %w", SanitizeAPIKeyForLog(ref.ID), err)
+		}
+		var apiKey APIKey
+		if err := doc.DataTo(&apiKey); err != nil {
+			return fmt.Errorf("failed to parse API key %s: %w", SanitizeAPIKeyForLog(ref.ID), err)
+		}
+		if !apiKey.IsActive || !apiKey.ExpiryTime.Before(now) {
+			return nil
+		}
+
+		metaRef := s.firestoreClient.Collection(apiKeysMetaCollection).Doc(apiKeysMetaDocument)
+		var metadata APIKeyMetadata
+		metaDoc, metaErr := tx.Get(metaRef)
+		if metaErr == nil {
+			metaDoc.DataTo(&metadata)
+		}
+
+		updates := map[string]interface{}{"is_active": false, "updated_at": now}
+		if err := tx.Update(ref, []firestore.Update{
+			{Path: "is_active", Value: false},
+			{Path: "updated_at", Value: now},
+		}); err != nil {
+			return err
+		}
+		if metaErr == nil {
+			if metadata.ActiveKeys > 0 {
+				metadata.ActiveKeys--
+			}
+			metadata.LastUpdated = now
+			if err := tx.Set(metaRef, metadata); err != nil {
+				return err
+			}
+		}
+		changed = true
+		return s.writeAudit(tx, newAuditEntry(ctx, AuditActionExpire, ref.ID, updates))
+	})
+	return changed, err
+}
+
+// RunExpirySweep deactivates expired keys every interval until ctx is done.
+func (s *APIKeyService) RunExpirySweep(ctx context.Context, interval time.Duration) {
+	log.Printf("🧹 API key expiry sweep every %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.SweepExpiredKeys(ctx); err != nil {
+			log.Printf("⚠️ API key expiry sweep failed: %v", err)
+		} else if count > 0 {
+			log.Printf("🧹 Deactivated %d expired API keys", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jobsCacheIndexKey is a Redis hash mapping each cached /jobs response key to
+// the encoded filter params it was computed from. It lives under "response:"
+// so DELETE /cache/clear drops it along with the entries it describes.
+//
+// Filter-scoped invalidation reads this index instead of scanning: cache keys
+// are hashes, so the filters cannot be recovered from the key itself. Entries
+// are written alongside each cache write and removed when invalidated; stale
+// entries for naturally expired responses are harmless and the hash expires
+// once no response has been cached for longer than the longest TTL used.
+const jobsCacheIndexKey = "response:index:jobs"
+
+// indexJobsCacheKey records which filters produced a cached /jobs response.
+func (s *Server) indexJobsCacheKey(ctx context.Context, cacheKey string, filters url.Values, ttl time.Duration) {
+	client, indexKey := s.redisClient.client, s.redisClient.key(jobsCacheIndexKey)
+	if err := client.HSet(ctx, indexKey, cacheKey, filters.Encode()).Err(); err != nil {
+		log.Printf("⚠️ Failed to index cache key: %v", err)
+		return
+	}
+	if current, err := client.TTL(ctx, indexKey).Result(); err == nil && current < ttl {
+		client.Expire(ctx, indexKey, ttl)
+	}
+}
+
+// cacheFiltersMatch reports whether a cached query could include jobs
+// selected by filter. A cached query that does not constrain a filtered param
+// matches, since its results may contain affected jobs; one that does must
+// share at least one comma-separated value (case-insensitive).
+func cacheFiltersMatch(cached, filter url.Values) bool {
+	for key, values := range filter {
+		cachedValues, ok := cached[key]
+		if !ok {
+			continue
+		}
+		if !valuesOverlap(cachedValues, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func valuesOverlap(a, b []string) bool {
+	seen := make(map[string]struct{})
+	for _, value := range a {
+		for _, part := range strings.Split(value, ",") {
+			seen[strings.ToLower(strings.TrimSpace(part))] = struct{}{}
+		}
+	}
+	for _, value := range b {
+		for _, part := range strings.Split(value, ",") {
+			if _, ok := seen[strings.ToLower(strings.TrimSpace(part))]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseCacheInvalidationFilter builds the filter for DELETE /admin/cache/jobs
+// from an optional upwork_url plus direct API filter params.
+func parseCacheInvalidationFilter(query url.Values) (url.Values, error) {
+	filter := url.Values{}
+	for key, values := range query {
+		lower := strings.ToLower(key)
+		if lower == "upwork_url" {
+			continue
+		}
+		if _, ok := supportedAPIParams[lower]; !ok {
+			return nil, fmt.Errorf("parameter '%s' is not a supported filter", key)
+		}
+		for _, value := range values {
+			if value = strings.TrimSpace(value); value != "" {
+				filter.Add(lower, value)
+			}
+		}
+	}
+
+	if raw := strings.TrimSpace(query.Get("upwork_url")); raw != "" {
+		derived, err := ParseUpworkSearchURL(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upwork_url: %w", err)
+		}
+		for key, values := range derived {
+			for _, value := range values {
+				filter.Add(key, value)
+			}
+		}
+	}
+
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("at least one filter is required; use DELETE /cache/clear to clear everything")
+	}
+	return filter, nil
+}
+
+// handleClearJobsCache clears cached /jobs responses affected by a filter
+// @Summary Clear cached /jobs responses by filter
+// @Description Removes cached /jobs responses whose filters overlap the given ones, using an index of cache keys to filters. Pass filter params directly (e.g. category=Web Development) or an upwork_url. Cached queries that do not constrain a given param are also cleared, since their results may include affected jobs.
+// @Tags admin
+// @Produce json
+// @Param upwork_url query string false "Upwork search URL whose filters select the entries to clear"
+// @Success 200 {object} JobsResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /admin/cache/jobs [delete]
+func (s *Server) handleClearJobsCache(c *gin.Context) {
+	filter, err := parseCacheInvalidationFilter(c.Request.URL.Query())
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	client, indexKey := s.redisClient.client, s.redisClient.key(jobsCacheIndexKey)
+	index, err := client.HGetAll(ctx, indexKey).Result()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Failed to read cache index: %v", err))
+		return
+	}
+
+	count := 0
+	for cacheKey, encoded := range index {
+		cached, err := url.ParseQuery(encoded)
+		if err != nil || !cacheFiltersMatch(cached, filter) {
+			continue
+		}
+		if err := s.redisClient.Delete(ctx, cacheKey); err != nil {
+			log.Printf("Failed to delete cache key %s: %v", cacheKey, err)
+			continue
+		}
+		client.HDel(ctx, indexKey, cacheKey)
+		count++
+	}
+
+	log.Printf("🗑️ Cleared %d /jobs cache entries matching %s", count, filter.Encode())
+	c.JSON(http.StatusOK, JobsResponse{
+		Success:     true,
+		Message:     fmt.Sprintf("Cleared %d cache entries", count),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCacheFiltersMatch(t *testing.T) {
+	filter := url.Values{"location": {"India"}, "search": {"python"}}
+
+	tests := []struct {
+		name   string
+		cached string
+		want   bool
+	}{
+		{"unconstrained query", "", true},
+		{"same values", "location=India&search=python", true},
+		{"overlapping list", "location=Pakistan%2C+india&search=python", true},
+		{"other location", "location=Pakistan&search=python", false},
+		{"other search", "search=react", false},
+		{"unrelated param", "payment_verified=true", true},
+	}
+	for _, tt := range tests {
+		cached, err := url.ParseQuery(tt.cached)
+		if err != nil {
+			t.Fatalf("%s: bad fixture: %v", tt.name, err)
+		}
+		if got := cacheFiltersMatch(cached, filter); got != tt.want {
+			t.Fatalf("%s: cacheFiltersMatch(%q) = %v, want %v", tt.name, tt.cached, got, tt.want)
+		}
+	}
+}
+
+func TestParseCacheInvalidationFilter(t *testing.T) {
+	filter, err := parseCacheInvalidationFilter(url.Values{
+		"Location":   {"India"},
+		"upwork_url": {"https://www.upwork.com/nx/search/jobs/?q=python"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.Get("location") != "India" || filter.Get("search") != "python" {
+		t.Fatalf("unexpected filter %v", filter)
+	}
+
+	if _, err := parseCacheInvalidationFilter(url.Values{}); err == nil {
+		t.Fatal("expected error for empty filter")
+	}
+	if _, err := parseCacheInvalidationFilter(url.Values{"bogus": {"1"}}); err == nil {
+		t.Fatal("expected error for unsupported param")
+	}
+}
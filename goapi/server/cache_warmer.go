@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultCacheWarmInterval is how often CACHE_WARM_URLS are re-queried. It
+// must stay below jobsCacheTTL so warmed entries are replaced before they
+// expire.
+const defaultCacheWarmInterval = jobsCacheTTL * 4 / 5
+
+// validateCacheWarmInterval rejects intervals that would let warmed entries
+// expire between runs.
+func validateCacheWarmInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("must be a positive duration")
+	}
+	if interval >= jobsCacheTTL {
+		return fmt.Errorf("must be shorter than the /jobs cache TTL of %v so entries are refreshed before they expire", jobsCacheTTL)
+	}
+	return nil
+}
+
+// parseCacheWarmURLs splits CACHE_WARM_URLS on "|" or newlines. Each entry is
+// an Upwork search URL exactly as clients pass it in upwork_url.
+func parseCacheWarmURLs(raw string) ([]string, error) {
+	fields := strings.FieldsFunc(raw, func(r rune) bool { return r == '|' || r == '\n' })
+	urls := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, err := ParseUpworkSearchURL(field); err != nil {
+			return nil, fmt.Errorf("invalid cache warm URL %q: %w", field, err)
+		}
+		urls = append(urls, field)
+	}
+	return urls, nil
+}
+
+// warmCacheKey matches the key handleJobs uses for /jobs?upwork_url=<rawURL>.
+func warmCacheKey(rawURL string) string {
+	return generateCacheKey("jobs", url.Values{"upwork_url": {rawURL}})
+}
+
+// runCacheWarmer re-runs the configured queries every interval until the
+// server shuts down. Entries get the normal jobsCacheTTL, so warmed data is
+// no staler than any other cached /jobs response; since the interval is
+// shorter than that TTL, each entry is replaced before it expires.
+func (s *Server) runCacheWarmer(urls []string, interval time.Duration) {
+	log.Printf("🔥 Cache warmer started: %d queries every %v", len(urls), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, rawURL := range urls {
+			if err := s.warmQuery(s.rootCtx, rawURL, jobsCacheTTL); err != nil {
+				log.Printf("⚠️ Cache warm failed for %s: %v", rawURL, err)
+			}
+		}
+
+		select {
+		case <-s.rootCtx.Done():
+			log.Printf("🛑 Cache warmer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// warmQuery runs one configured query and stores the response under the
+// same key a matching /jobs request would read.
+func (s *Server) warmQuery(ctx context.Context, rawURL string, ttl time.Duration) error {
+	derived, err := ParseUpworkSearchURL(rawURL)
+	if err != nil {
+		return err
+	}
+	opts, err := convertToFilterOptions(&JobsQueryParams{UpworkURL: rawURL, derivedParams: derived})
+	if err != nil {
+		return err
+	}
+	if opts.hasSortField(SortHot) && !s.features.Enabled(FeatureRelevanceSort) {
+		return fmt.Errorf("sort=hot is not enabled on this server")
+	}
+
+	response, err := s.jobsResponse(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if response.Partial {
+		return fmt.Errorf("query timed out with partial results; not caching")
+	}
+	cacheKey := warmCacheKey(rawURL)
+	if err := s.redisClient.Set(ctx, cacheKey, response, ttl); err != nil {
+		return err
+	}
+	s.indexJobsCacheKey(ctx, cacheKey, derived, ttl)
+	log.Printf("♨️ Warmed cache for %s (%d jobs)", rawURL, response.Count)
+	return nil
+}
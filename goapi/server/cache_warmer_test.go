@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCacheWarmURLs(t *testing.T) {
+	urls, err := parseCacheWarmURLs(" https://www.upwork.com/nx/search/jobs/?q=python |\nhttps://www.upwork.com/nx/search/jobs/?q=react&t=0|")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"https://www.upwork.com/nx/search/jobs/?q=python",
+		"https://www.upwork.com/nx/search/jobs/?q=react&t=0",
+	}
+	if !reflect.DeepEqual(urls, want) {
+		t.Fatalf("parseCacheWarmURLs() = %v, want %v", urls, want)
+	}
+
+	if urls, err := parseCacheWarmURLs(""); err != nil || len(urls) != 0 {
+		t.Fatalf("expected no URLs for empty config, got %v, %v", urls, err)
+	}
+}
+
+func TestWarmCacheKeyMatchesRequestKey(t *testing.T) {
+	rawURL := "https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40"
+	req := httptest.NewRequest("GET", "/jobs?upwork_url=https%3A%2F%2Fwww.upwork.com%2Fnx%2Fsearch%2Fjobs%2F%3Fq%3Dpython%26hourly_rate%3D20-40", nil)
+
+	if got, want := warmCacheKey(rawURL), generateCacheKey("jobs", req.URL.Query()); got != want {
+		t.Fatalf("warm key %s does not match request key %s", got, want)
+	}
+}
+
+func TestValidateCacheWarmInterval(t *testing.T) {
+	if err := validateCacheWarmInterval(defaultCacheWarmInterval); err != nil {
+		t.Fatalf("expected the default interval to be valid: %v", err)
+	}
+	for _, interval := range []time.Duration{0, -time.Second, jobsCacheTTL, time.Minute} {
+		if err := validateCacheWarmInterval(interval); err == nil {
+			t.Fatalf("%v: expected an error", interval)
+		}
+	}
+}
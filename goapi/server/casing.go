@@ -0,0 +1,65 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Response key casings accepted by the case parameter
+const (
+	caseSnake = "snake"
+	caseCamel = "camel"
+)
+
+// camelizeJSON re-keys a JSON-serializable value from snake_case to camelCase.
+// Numbers are kept verbatim so IDs and amounts are not rounded.
+func camelizeJSON(value interface{}) (interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return camelizeKeys(generic), nil
+}
+
+func camelizeKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[snakeToCamel(key)] = camelizeKeys(child)
+		}
+		return out
+	case []interface{}:
+		for i, child := range v {
+			v[i] = camelizeKeys(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// snakeToCamel converts "last_updated" to "lastUpdated".
+func snakeToCamel(key string) string {
+	if !strings.Contains(key, "_") {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	var builder strings.Builder
+	builder.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		builder.WriteString(strings.ToUpper(part[:1]))
+		builder.WriteString(part[1:])
+	}
+	return builder.String()
+}
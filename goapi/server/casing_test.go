@@ -0,0 +1,56 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSnakeToCamel(t *testing.T) {
+	tests := map[string]string{
+		"last_updated":          "lastUpdated",
+		"total_jobs_with_hires": "totalJobsWithHires",
+		"success":               "success",
+		"_private":              "Private",
+	}
+	for in, want := range tests {
+		if got := snakeToCamel(in); got != want {
+			t.Fatalf("snakeToCamel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRespondJobsCamelCase(t *testing.T) {
+	verified := true
+	response := JobsResponse{
+		Success:     true,
+		Data:        []JobDTO{{ID: "1234567890123456789", Buyer: &BuyerDTO{PaymentVerified: &verified}}},
+		Count:       1,
+		LastUpdated: "2025-01-10T12:00:00Z",
+	}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs?case=camel", nil)
+	respondJobs(c, response)
+
+	body := rec.Body.String()
+	if strings.Contains(body, "last_updated") || strings.Contains(body, "payment_verified") {
+		t.Fatalf("expected camelCase keys, got %s", body)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	job := decoded["data"].([]interface{})[0].(map[string]interface{})
+	if decoded["lastUpdated"] != response.LastUpdated || job["id"] != "1234567890123456789" {
+		t.Fatalf("unexpected camelCase body: %s", body)
+	}
+	if buyer := job["buyer"].(map[string]interface{}); buyer["paymentVerified"] != true {
+		t.Fatalf("expected nested keys to be re-keyed: %s", body)
+	}
+}
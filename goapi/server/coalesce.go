@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// jobsCoalescer collapses concurrent identical /jobs cache misses into a
+// single Firestore query and counts how much work that saved.
+type jobsCoalescer struct {
+	group      singleflight.Group
+	executed   atomic.Int64 // queries that actually hit Firestore
+	coalesced  atomic.Int64 // requests served by another request's query
+	savedReads atomic.Int64 // Firestore document reads avoided by coalescing
+}
+
+// coalescedResult carries a shared response and what it cost to build.
+type coalescedResult struct {
+	response JobsResponse
+	reads    int64
+}
+
+// do runs fn once per key among concurrent callers. shared is true for
+// callers that reused another caller's result.
+func (c *jobsCoalescer) do(ctx context.Context, key string, fn func(context.Context) (JobsResponse, error)) (JobsResponse, bool, error) {
+	leader := false
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		leader = true
+		c.executed.Add(1)
+
+		readCtx, reads := withReadCounter(ctx)
+		response, err := fn(readCtx)
+		return coalescedResult{response: response, reads: reads.Load()}, err
+	})
+
+	result, _ := value.(coalescedResult)
+	if !leader {
+		c.coalesced.Add(1)
+		c.savedReads.Add(result.reads)
+	}
+	return result.response, !leader, err
+}
+
+// stats reports the coalescing counters for /cache/stats.
+func (c *jobsCoalescer) stats() map[string]int64 {
+	return map[string]int64{
+		"queries_executed":      c.executed.Load(),
+		"requests_coalesced":    c.coalesced.Load(),
+		"firestore_reads_saved": c.savedReads.Load(),
+	}
+}
+
+type readCounterKey struct{}
+
+// withReadCounter attaches a counter that collectJobs adds document reads to.
+func withReadCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := &atomic.Int64{}
+	return context.WithValue(ctx, readCounterKey{}, counter), counter
+}
+
+// addDocReads records n document reads against the counter in ctx, if any.
+func addDocReads(ctx context.Context, n int) {
+	if counter, ok := ctx.Value(readCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(int64(n))
+	}
+}
+
+// inheritReadCounter copies the read counter from src onto dst, for contexts
+// derived from the server root rather than the request.
+func inheritReadCounter(dst, src context.Context) context.Context {
+	if src == nil {
+		return dst
+	}
+	if counter, ok := src.Value(readCounterKey{}).(*atomic.Int64); ok {
+		return context.WithValue(dst, readCounterKey{}, counter)
+	}
+	return dst
+}
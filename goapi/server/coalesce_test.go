@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobsCoalescerSharesInFlightQuery(t *testing.T) {
+	var c jobsCoalescer
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]bool, 3)
+	query := func(ctx context.Context) (JobsResponse, error) {
+		close(started)
+		<-release
+		addDocReads(ctx, 120)
+		return JobsResponse{Success: true, Count: 7}, nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, shared, _ := c.do(context.Background(), "k", query)
+		results[0] = shared
+	}()
+	<-started
+
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, shared, err := c.do(context.Background(), "k", func(context.Context) (JobsResponse, error) {
+				t.Errorf("follower must not execute its own query")
+				return JobsResponse{}, nil
+			})
+			if err != nil || resp.Count != 7 {
+				t.Errorf("follower got %+v, %v", resp, err)
+			}
+			results[i] = shared
+		}(i)
+	}
+
+	// Give the followers time to join the in-flight call before releasing it
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if results[0] || !results[1] || !results[2] {
+		t.Fatalf("expected only followers to be shared, got %v", results)
+	}
+	stats := c.stats()
+	if stats["queries_executed"] != 1 || stats["requests_coalesced"] != 2 || stats["firestore_reads_saved"] != 240 {
+		t.Fatalf("unexpected stats %v", stats)
+	}
+}
+
+func TestJobsCoalescerSequentialCallsExecute(t *testing.T) {
+	var c jobsCoalescer
+	for i := 0; i < 2; i++ {
+		_, shared, err := c.do(context.Background(), "k", func(ctx context.Context) (JobsResponse, error) {
+			addDocReads(ctx, 10)
+			return JobsResponse{}, nil
+		})
+		if err != nil || shared {
+			t.Fatalf("call %d: shared=%v err=%v", i, shared, err)
+		}
+	}
+	if stats := c.stats(); stats["queries_executed"] != 2 || stats["requests_coalesced"] != 0 || stats["firestore_reads_saved"] != 0 {
+		t.Fatalf("unexpected stats %v", stats)
+	}
+}
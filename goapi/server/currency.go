@@ -0,0 +1,90 @@
+package server
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type currencyFormat struct {
+	symbol   string
+	decimals int // digits shown when the amount has a fractional part
+}
+
+// currencyFormats is a small symbol table for the currencies Upwork quotes.
+// Unknown codes fall back to "1,200 XYZ".
+var currencyFormats = map[string]currencyFormat{
+	"USD": {symbol: "$", decimals: 2},
+	"EUR": {symbol: "€", decimals: 2},
+	"GBP": {symbol: "£", decimals: 2},
+	"CAD": {symbol: "CA$", decimals: 2},
+	"AUD": {symbol: "A$", decimals: 2},
+	"INR": {symbol: "₹", decimals: 2},
+	"JPY": {symbol: "¥", decimals: 0},
+}
+
+// formatCurrency renders amount for display, e.g. 1200 USD -> "$1,200" and
+// 99.5 EUR -> "€99.50". An empty code is treated as USD, Upwork's default.
+func formatCurrency(amount float64, code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		code = "USD"
+	}
+	format, known := currencyFormats[code]
+	if !known {
+		format = currencyFormat{decimals: 2}
+	}
+
+	decimals := 0
+	if format.decimals > 0 && math.Abs(amount-math.Round(amount)) >= 0.005 {
+		decimals = format.decimals
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	number := groupThousands(strconv.FormatFloat(amount, 'f', decimals, 64))
+
+	if !known {
+		return sign + number + " " + code
+	}
+	return sign + format.symbol + number
+}
+
+// groupThousands inserts commas into the integer part of a formatted number.
+func groupThousands(number string) string {
+	intPart, fracPart, hasFrac := strings.Cut(number, ".")
+	if len(intPart) <= 3 {
+		return number
+	}
+
+	var builder strings.Builder
+	lead := len(intPart) % 3
+	if lead > 0 {
+		builder.WriteString(intPart[:lead])
+	}
+	for i := lead; i < len(intPart); i += 3 {
+		if builder.Len() > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(intPart[i : i+3])
+	}
+	if hasFrac {
+		builder.WriteByte('.')
+		builder.WriteString(fracPart)
+	}
+	return builder.String()
+}
+
+// withDisplay returns a copy of budget carrying FixedAmountDisplay, leaving
+// the record's own BudgetInfo untouched.
+func (b *BudgetInfo) withDisplay() *BudgetInfo {
+	if b == nil || b.FixedAmount == nil {
+		return b
+	}
+	copied := *b
+	copied.FixedAmountDisplay = formatCurrency(*b.FixedAmount, b.Currency)
+	return &copied
+}
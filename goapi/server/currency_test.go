@@ -0,0 +1,42 @@
+package server
+
+import "testing"
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		amount float64
+		code   string
+		want   string
+	}{
+		{amount: 1200, code: "USD", want: "$1,200"},
+		{amount: 1234567.5, code: "usd", want: "$1,234,567.50"},
+		{amount: 99.5, code: "EUR", want: "€99.50"},
+		{amount: 250, code: "", want: "$250"},
+		{amount: 15000.4, code: "JPY", want: "¥15,000"},
+		{amount: 5000, code: "CHF", want: "5,000 CHF"},
+		{amount: -1500, code: "GBP", want: "-£1,500"},
+	}
+
+	for _, tc := range tests {
+		if got := formatCurrency(tc.amount, tc.code); got != tc.want {
+			t.Fatalf("formatCurrency(%v, %q) = %q, want %q", tc.amount, tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestToDTOWithFormatCurrency(t *testing.T) {
+	amount := 1200.0
+	job := JobRecord{ID: "a", Budget: &BudgetInfo{FixedAmount: &amount, Currency: "USD"}}
+
+	if dto := job.ToDTO(); dto.Budget.FixedAmountDisplay != "" {
+		t.Fatalf("expected no display string by default, got %q", dto.Budget.FixedAmountDisplay)
+	}
+
+	dto := job.ToDTOWith(DTOOptions{FormatCurrency: true})
+	if dto.Budget.FixedAmountDisplay != "$1,200" || dto.Budget.FixedAmount == nil || *dto.Budget.FixedAmount != amount {
+		t.Fatalf("unexpected formatted budget: %+v", dto.Budget)
+	}
+	if job.Budget.FixedAmountDisplay != "" {
+		t.Fatalf("formatting must not mutate the record's budget")
+	}
+}
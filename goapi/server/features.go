@@ -0,0 +1,52 @@
+package server
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// Feature flag names accepted in FEATURES.
+const (
+	// FeatureRelevanceSort enables sort=hot ranking.
+	FeatureRelevanceSort = "relevance_sort"
+)
+
+// knownFeatures lists the flags handlers check; unknown names are ignored.
+var knownFeatures = map[string]struct{}{
+	FeatureRelevanceSort: {},
+}
+
+// FeatureFlags is the set of features enabled via FEATURES. Everything is
+// off unless listed.
+type FeatureFlags map[string]struct{}
+
+// ParseFeatureFlags reads a comma-separated list such as
+// "relevance_sort,csv_output". Unknown names are logged and skipped.
+func ParseFeatureFlags(raw string) FeatureFlags {
+	flags := make(FeatureFlags)
+	for _, name := range parseCSVLower(raw) {
+		if _, ok := knownFeatures[name]; !ok {
+			log.Printf("⚠️ Ignoring unknown feature flag %q", name)
+			continue
+		}
+		flags[name] = struct{}{}
+	}
+	return flags
+}
+
+// Enabled reports whether the named feature is on.
+func (f FeatureFlags) Enabled(name string) bool {
+	_, ok := f[strings.ToLower(name)]
+	return ok
+}
+
+// Names returns the enabled flags in sorted order.
+func (f FeatureFlags) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
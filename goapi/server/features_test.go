@@ -0,0 +1,23 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFeatureFlags(t *testing.T) {
+	flags := ParseFeatureFlags(" Relevance_Sort , not_a_feature,")
+	if !flags.Enabled(FeatureRelevanceSort) {
+		t.Fatalf("expected relevance_sort to be enabled")
+	}
+	if flags.Enabled("not_a_feature") {
+		t.Fatalf("unknown flags must be ignored")
+	}
+	if want := []string{FeatureRelevanceSort}; !reflect.DeepEqual(flags.Names(), want) {
+		t.Fatalf("Names() = %v, want %v", flags.Names(), want)
+	}
+
+	if empty := ParseFeatureFlags(""); empty.Enabled(FeatureRelevanceSort) || len(empty.Names()) != 0 {
+		t.Fatalf("expected every feature off by default, got %v", empty.Names())
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// fetchStats records how many fetched documents matched the filters in the
+// most recent fetch of a /jobs query, before offset and limit were applied.
+type fetchStats struct {
+	matched atomic.Int64
+	capped  atomic.Bool
+}
+
+// record overwrites the previous fetch; replica fallbacks and min_results
+// relaxation re-run the fetch, and only the last run shapes the response.
+func (f *fetchStats) record(matched int, capped bool) {
+	f.matched.Store(int64(matched))
+	f.capped.Store(capped)
+}
+
+type fetchStatsKey struct{}
+
+// withFetchStats attaches a matched-count recorder to ctx.
+func withFetchStats(ctx context.Context) (context.Context, *fetchStats) {
+	stats := &fetchStats{}
+	return context.WithValue(ctx, fetchStatsKey{}, stats), stats
+}
+
+// fetchStatsFrom returns the recorder in ctx, or nil when none is attached.
+func fetchStatsFrom(ctx context.Context) *fetchStats {
+	stats, _ := ctx.Value(fetchStatsKey{}).(*fetchStats)
+	return stats
+}
+
+// inheritFetchStats copies the recorder from src onto dst, for contexts
+// derived from the server root rather than the request.
+func inheritFetchStats(dst, src context.Context) context.Context {
+	if src == nil {
+		return dst
+	}
+	if stats := fetchStatsFrom(src); stats != nil {
+		return context.WithValue(dst, fetchStatsKey{}, stats)
+	}
+	return dst
+}
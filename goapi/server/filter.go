@@ -2,10 +2,12 @@ package server
 
 import (
 	"fmt"
+	"log"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -79,31 +81,103 @@ type FilterOptions struct {
 	DurationLabels      []string
 	WorkloadValues      []string
 	ContractToHire      *bool
+	HasCategory         *bool // true keeps only categorized jobs, false only uncategorized
+	RequireTitle        bool  // drop jobs whose title is blank
+	HasHourly           *bool // presence of a positive hourly rate, regardless of job type
+	HasFixed            *bool // presence of a positive fixed budget, regardless of job type
 	BudgetRanges        []NumericRange
 	HourlyRanges        []NumericRange
+	MinPay              *float64 // fixed budget OR hourly max must reach this
 	ClientHiresRanges   []IntRange
+	ClientSpendTier     string // canonical clientSpendTiers label
+	ClientReviewsRanges []IntRange
+	CompanySizeRanges   []IntRange
+	InvitationsRanges   []IntRange
+	InterviewingRanges  []IntRange // freelancers invited to interview
+	Industries          []string
 	LocationRegions     []string
+	ExcludedCountries   []string // canonical country codes/names or group keys to drop
 	Timezones           []string
 	Proposals           []string
 	PreviousClients     string
 	CategoryGroupIDs    []string
-	SortField           sortField
+	SortField           sortField // primary sort key, used for Firestore ordering
 	SortAscending       bool
+	SortKeys            []sortKey      // full ordered sort keys when several were given
+	StrictOrder         bool           // false merges in documents lacking the Firestore order field
+	MaxStaleness        *time.Duration // nil applies the server default; 0 disables the cutoff
+	BuyerActiveWithin   *time.Duration // buyer's last activity must fall in this window
+	PublishedAfter      *time.Time     // set from since_id; keeps jobs published strictly later
+	IncludeSimilar      bool           // add similarJobs from private job pages
+	StemSearch          bool           // match search terms by stem as well
+	IDsOnly             bool           // respond with job IDs instead of DTOs
+	MinResults          int            // relax filters until this many jobs match; 0 disables
+	FormatCurrency      bool           // render budget display strings in the DTO
+	OutputLocation      *time.Location // zone for emitted timestamps; nil means UTC
 	SearchQuery         string
-	SearchExpression    *SearchExpression
+	ExcludeQuery        string
+	SearchExpression    *SearchExpression // search ANDed with NOT exclude
 	UpworkURL           string
 }
 
+// defaultLimit is the page size used when a request omits limit.
+var defaultLimit = fallbackDefaultLimit
+
+// ConfigureDefaultLimit sets the default page size from DEFAULT_LIMIT. It must
+// be a positive integer no larger than maxLimit; on error the fallback is kept.
+func ConfigureDefaultLimit(raw string) error {
+	limit, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || limit <= 0 {
+		defaultLimit = fallbackDefaultLimit
+		return fmt.Errorf("default limit must be a positive integer, got %q", raw)
+	}
+	if limit > maxLimit {
+		defaultLimit = fallbackDefaultLimit
+		return fmt.Errorf("default limit %d exceeds the maximum of %d", limit, maxLimit)
+	}
+	defaultLimit = limit
+	return nil
+}
+
+// requireTitleDefault applies require_title when a request omits it.
+var requireTitleDefault bool
+
+// ConfigureRequireTitle sets the require_title default from REQUIRE_TITLE.
+func ConfigureRequireTitle(raw string) error {
+	parsed, err := parseFlexibleBool(raw)
+	if err != nil {
+		return fmt.Errorf("expected a boolean, got %q", raw)
+	}
+	requireTitleDefault = parsed
+	return nil
+}
+
 func parseFilterOptions(values url.Values) (FilterOptions, error) {
 	opts := FilterOptions{
 		Limit:         defaultLimit,
 		SortField:     DefaultSortField,
 		SortAscending: DefaultSortAscending,
+		StrictOrder:   true,
+		RequireTitle:  requireTitleDefault,
 	}
 
 	opts.UpworkURL = strings.TrimSpace(firstQuery(values, "upwork_url"))
 
+	// Read before search so ApplySearchQuery can stem the parsed terms
+	if raw := firstQuery(values, "stem"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid stem parameter")
+		}
+		opts.StemSearch = parsed
+	}
+
+	// search and q are aliases; identical values are fine, differing ones are
+	// rejected rather than silently preferring search
 	if raw := firstQuery(values, "search"); raw != "" {
+		if q := firstQuery(values, "q"); q != "" && searchValuesConflict(raw, q) {
+			return opts, fmt.Errorf("conflicting search and q parameters; send only one")
+		}
 		if err := opts.ApplySearchQuery(raw); err != nil {
 			return opts, err
 		}
@@ -113,6 +187,12 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 		}
 	}
 
+	if raw := firstQuery(values, "exclude"); raw != "" {
+		if err := opts.ApplyExcludeQuery(raw); err != nil {
+			return opts, fmt.Errorf("invalid exclude parameter: %w", err)
+		}
+	}
+
 	if raw := firstQuery(values, "limit"); raw != "" {
 		limit, err := strconv.Atoi(raw)
 		if err != nil || limit <= 0 {
@@ -151,6 +231,38 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 		opts.ContractToHire = &parsed
 	}
 
+	if raw := firstQuery(values, "has_category"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid has_category parameter")
+		}
+		opts.HasCategory = &parsed
+	}
+
+	if raw := firstQuery(values, "require_title"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid require_title parameter")
+		}
+		opts.RequireTitle = parsed
+	}
+
+	if raw := firstQuery(values, "has_hourly"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid has_hourly parameter")
+		}
+		opts.HasHourly = &parsed
+	}
+
+	if raw := firstQuery(values, "has_fixed"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid has_fixed parameter")
+		}
+		opts.HasFixed = &parsed
+	}
+
 	if raw := firstQuery(values, "contractor_tier"); raw != "" {
 		tiers, err := parseContractorTierList(raw)
 		if err != nil {
@@ -159,7 +271,12 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 		opts.ContractorTierCodes = tiers
 	}
 
+	// t and job_type are aliases; both may be sent only when they name the
+	// same job types, otherwise the request is rejected rather than t winning
 	if raw := firstQuery(values, "t"); raw != "" {
+		if other := firstQuery(values, "job_type"); other != "" && jobTypeValuesConflict(raw, other) {
+			return opts, fmt.Errorf("conflicting t and job_type parameters; send only one")
+		}
 		types, err := parseJobTypeList(raw)
 		if err != nil {
 			return opts, err
@@ -197,6 +314,14 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 		opts.HourlyRanges = ranges
 	}
 
+	if raw := firstQuery(values, "min_pay"); raw != "" {
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || value < 0 {
+			return opts, fmt.Errorf("invalid min_pay parameter: must be a non-negative number")
+		}
+		opts.MinPay = &value
+	}
+
 	if raw := firstQuery(values, "client_hires"); raw != "" {
 		ranges, err := parseIntRanges(raw)
 		if err != nil {
@@ -205,10 +330,60 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 		opts.ClientHiresRanges = ranges
 	}
 
+	if raw := firstQuery(values, "client_spend_tier"); raw != "" {
+		tier, err := parseClientSpendTier(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid client_spend_tier parameter: %w", err)
+		}
+		opts.ClientSpendTier = tier
+	}
+
+	if raw := firstQuery(values, "client_reviews"); raw != "" {
+		ranges, err := parseIntRanges(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid client_reviews parameter: %w", err)
+		}
+		opts.ClientReviewsRanges = ranges
+	}
+
+	if raw := firstQuery(values, "company_size"); raw != "" {
+		ranges, err := parseIntRanges(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid company_size parameter: %w", err)
+		}
+		opts.CompanySizeRanges = ranges
+	}
+
+	if raw := firstQuery(values, "invitations"); raw != "" {
+		ranges, err := parseIntRanges(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid invitations parameter: %w", err)
+		}
+		opts.InvitationsRanges = ranges
+	}
+
+	if raw := firstQuery(values, "interviewing"); raw != "" {
+		ranges, err := parseInterviewing(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid interviewing parameter: %w", err)
+		}
+		opts.InterviewingRanges = ranges
+	}
+
+	if raw := firstQuery(values, "industry"); raw != "" {
+		opts.Industries = parseCSVLower(raw)
+	}
+
 	if raw := firstQuery(values, "location"); raw != "" {
 		opts.LocationRegions = parseCSVLower(raw)
 	}
 
+	if raw := firstQuery(values, "country_exclude"); raw != "" {
+		for _, country := range parseCSV(raw) {
+			opts.ExcludedCountries = append(opts.ExcludedCountries, canonicalCountry(country))
+		}
+	}
+
 	if raw := firstQuery(values, "timezone"); raw != "" {
 		opts.Timezones = parseCSV(raw)
 	}
@@ -226,7 +401,74 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 	}
 
 	if raw := firstQuery(values, "sort"); raw != "" {
-		applySortParam(&opts, raw)
+		if err := applySortParam(&opts, raw); err != nil {
+			return opts, err
+		}
+	}
+
+	if raw := firstQuery(values, "buyer_active_within"); raw != "" {
+		window, err := parseStaleness(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid buyer_active_within parameter: %w", err)
+		}
+		if window > 0 {
+			opts.BuyerActiveWithin = &window
+		}
+	}
+
+	if raw := firstQuery(values, "max_staleness"); raw != "" {
+		staleness, err := parseStaleness(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid max_staleness parameter: %w", err)
+		}
+		opts.MaxStaleness = &staleness
+	}
+
+	if raw := firstQuery(values, "strict_order"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid strict_order parameter")
+		}
+		opts.StrictOrder = parsed
+	}
+
+	if raw := firstQuery(values, "include_similar"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid include_similar parameter")
+		}
+		opts.IncludeSimilar = parsed
+	}
+
+	if raw := firstQuery(values, "ids_only"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid ids_only parameter")
+		}
+		opts.IDsOnly = parsed
+	}
+
+	if raw := firstQuery(values, "min_results"); raw != "" {
+		minResults, err := strconv.Atoi(raw)
+		if err != nil || minResults < 0 {
+			return opts, fmt.Errorf("invalid min_results parameter")
+		}
+		if minResults > opts.Limit {
+			return opts, fmt.Errorf("min_results cannot exceed limit (%d)", opts.Limit)
+		}
+		opts.MinResults = minResults
+	}
+
+	if raw := firstQuery(values, "format_currency"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid format_currency parameter")
+		}
+		opts.FormatCurrency = parsed
+	}
+
+	if raw := firstQuery(values, "tz"); raw != "" {
+		opts.OutputLocation = parseOutputLocation(raw)
 	}
 
 	if raw := firstQuery(values, "upwork_url"); raw != "" {
@@ -237,6 +479,12 @@ func parseFilterOptions(values url.Values) (FilterOptions, error) {
 }
 
 func formatFilterOptions(opts FilterOptions) string {
+	return strings.Join(filterOptionParts(opts), ", ")
+}
+
+// filterOptionParts renders the set options as name=value pairs using the
+// /jobs parameter names, for logs and the URL parse preview.
+func filterOptionParts(opts FilterOptions) []string {
 	parts := []string{fmt.Sprintf("limit=%d", opts.Limit)}
 
 	if opts.Offset > 0 {
@@ -260,18 +508,54 @@ func formatFilterOptions(opts FilterOptions) string {
 	if opts.ContractToHire != nil {
 		parts = append(parts, fmt.Sprintf("contract_to_hire=%t", *opts.ContractToHire))
 	}
+	if opts.HasCategory != nil {
+		parts = append(parts, fmt.Sprintf("has_category=%t", *opts.HasCategory))
+	}
+	if opts.RequireTitle {
+		parts = append(parts, "require_title=true")
+	}
+	if opts.HasHourly != nil {
+		parts = append(parts, fmt.Sprintf("has_hourly=%t", *opts.HasHourly))
+	}
+	if opts.HasFixed != nil {
+		parts = append(parts, fmt.Sprintf("has_fixed=%t", *opts.HasFixed))
+	}
 	if len(opts.BudgetRanges) > 0 {
 		parts = append(parts, fmt.Sprintf("amount=%s", joinNumericRanges(opts.BudgetRanges)))
 	}
 	if len(opts.HourlyRanges) > 0 {
 		parts = append(parts, fmt.Sprintf("hourly_rate=%s", joinNumericRanges(opts.HourlyRanges)))
 	}
+	if opts.MinPay != nil {
+		parts = append(parts, fmt.Sprintf("min_pay=%g", *opts.MinPay))
+	}
 	if len(opts.ClientHiresRanges) > 0 {
 		parts = append(parts, fmt.Sprintf("client_hires=%s", joinIntRanges(opts.ClientHiresRanges)))
 	}
+	if opts.ClientSpendTier != "" {
+		parts = append(parts, fmt.Sprintf("client_spend_tier=%s", opts.ClientSpendTier))
+	}
+	if len(opts.ClientReviewsRanges) > 0 {
+		parts = append(parts, fmt.Sprintf("client_reviews=%s", joinIntRanges(opts.ClientReviewsRanges)))
+	}
+	if len(opts.CompanySizeRanges) > 0 {
+		parts = append(parts, fmt.Sprintf("company_size=%s", joinIntRanges(opts.CompanySizeRanges)))
+	}
+	if len(opts.InvitationsRanges) > 0 {
+		parts = append(parts, fmt.Sprintf("invitations=%s", joinIntRanges(opts.InvitationsRanges)))
+	}
+	if len(opts.InterviewingRanges) > 0 {
+		parts = append(parts, fmt.Sprintf("interviewing=%s", joinIntRanges(opts.InterviewingRanges)))
+	}
+	if len(opts.Industries) > 0 {
+		parts = append(parts, fmt.Sprintf("industry=%s", strings.Join(opts.Industries, ",")))
+	}
 	if len(opts.LocationRegions) > 0 {
 		parts = append(parts, fmt.Sprintf("location=%s", strings.Join(opts.LocationRegions, ",")))
 	}
+	if len(opts.ExcludedCountries) > 0 {
+		parts = append(parts, fmt.Sprintf("country_exclude=%s", strings.Join(opts.ExcludedCountries, ",")))
+	}
 	if len(opts.Timezones) > 0 {
 		parts = append(parts, fmt.Sprintf("timezone=%s", strings.Join(opts.Timezones, ",")))
 	}
@@ -287,32 +571,51 @@ func formatFilterOptions(opts FilterOptions) string {
 	if opts.SearchQuery != "" {
 		parts = append(parts, fmt.Sprintf("search=%q", opts.SearchQuery))
 	}
+	if opts.ExcludeQuery != "" {
+		parts = append(parts, fmt.Sprintf("exclude=%q", opts.ExcludeQuery))
+	}
+	if !opts.StrictOrder {
+		parts = append(parts, "strict_order=false")
+	}
+	if opts.IncludeSimilar {
+		parts = append(parts, "include_similar=true")
+	}
+	if opts.StemSearch {
+		parts = append(parts, "stem=true")
+	}
+	if opts.IDsOnly {
+		parts = append(parts, "ids_only=true")
+	}
+	if opts.FormatCurrency {
+		parts = append(parts, "format_currency=true")
+	}
+	if opts.OutputLocation != nil {
+		parts = append(parts, fmt.Sprintf("tz=%s", opts.OutputLocation))
+	}
+	if opts.BuyerActiveWithin != nil {
+		parts = append(parts, fmt.Sprintf("buyer_active_within=%v", *opts.BuyerActiveWithin))
+	}
+	if opts.MinResults > 0 {
+		parts = append(parts, fmt.Sprintf("min_results=%d", opts.MinResults))
+	}
+	if opts.PublishedAfter != nil {
+		parts = append(parts, fmt.Sprintf("published_after=%s", opts.PublishedAfter.Format(time.RFC3339)))
+	}
+	if opts.MaxStaleness != nil {
+		parts = append(parts, fmt.Sprintf("max_staleness=%v", *opts.MaxStaleness))
+	}
 	if opts.UpworkURL != "" {
 		parts = append(parts, fmt.Sprintf("upwork_url=%s", opts.UpworkURL))
 	}
 
-	sortLabel := "last_visited_desc"
-	switch opts.SortField {
-	case SortPublishTime:
-		if opts.SortAscending {
-			sortLabel = "publish_time_asc"
-		} else {
-			sortLabel = "publish_time_desc"
-		}
-	case SortBudget:
-		if opts.SortAscending {
-			sortLabel = "budget_asc"
-		} else {
-			sortLabel = "budget_desc"
-		}
-	default:
-		if opts.SortAscending {
-			sortLabel = "last_visited_asc"
-		}
+	sortLabels := make([]string, 0, len(opts.sortKeys()))
+	for _, key := range opts.sortKeys() {
+		sortLabels = append(sortLabels, key.label())
 	}
+	sortLabel := strings.Join(sortLabels, ",")
 	parts = append(parts, fmt.Sprintf("sort=%s", sortLabel))
 
-	return strings.Join(parts, ", ")
+	return parts
 }
 
 func (opts *FilterOptions) ApplySearchQuery(raw string) error {
@@ -332,11 +635,45 @@ func (opts *FilterOptions) ApplySearchQuery(raw string) error {
 		return err
 	}
 
+	if opts.StemSearch {
+		expr.EnableStemming()
+	}
+
 	opts.SearchQuery = trimmed
 	opts.SearchExpression = expr
 	return nil
 }
 
+// ApplyExcludeQuery narrows SearchExpression to jobs that match none of the
+// excluded terms. Call it after ApplySearchQuery, which replaces the
+// expression.
+func (opts *FilterOptions) ApplyExcludeQuery(raw string) error {
+	if opts == nil {
+		return fmt.Errorf("filter options not initialized")
+	}
+
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil
+	}
+
+	expr, err := ParseExcludeQuery(trimmed)
+	if err != nil {
+		return err
+	}
+	if expr.root == nil {
+		return nil
+	}
+
+	if opts.StemSearch {
+		expr.EnableStemming()
+	}
+
+	opts.ExcludeQuery = trimmed
+	opts.SearchExpression = opts.SearchExpression.and(expr)
+	return nil
+}
+
 func parseFlexibleBool(value string) (bool, error) {
 	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "1", "true", "yes", "y", "on":
@@ -421,6 +758,28 @@ func parseJobTypeList(raw string) ([]int, error) {
 	return result, nil
 }
 
+// jobTypeValuesConflict reports whether two job type lists name different
+// job types. Values that do not parse are left for parseJobTypeList to reject.
+func jobTypeValuesConflict(a, b string) bool {
+	aCodes, err := parseJobTypeList(a)
+	if err != nil {
+		return false
+	}
+	bCodes, err := parseJobTypeList(b)
+	if err != nil {
+		return false
+	}
+	if len(aCodes) != len(bCodes) {
+		return true
+	}
+	for i := range aCodes {
+		if aCodes[i] != bCodes[i] {
+			return true
+		}
+	}
+	return false
+}
+
 func parseDurationTokens(raw string) []string {
 	tokens := strings.Split(raw, ",")
 	result := make([]string, 0, len(tokens))
@@ -442,6 +801,78 @@ func parseDurationTokens(raw string) []string {
 	return result
 }
 
+// parseOutputLocation loads an IANA zone for emitted timestamps. Unknown
+// zones fall back to UTC rather than failing the request.
+func parseOutputLocation(raw string) *time.Location {
+	name := strings.TrimSpace(raw)
+	loc, err := time.LoadLocation(name)
+	// "Local" would leak the server's own zone
+	if err != nil || strings.EqualFold(name, "local") {
+		log.Printf("⚠️ Unknown tz %q, emitting UTC timestamps", raw)
+		return time.UTC
+	}
+	return loc
+}
+
+// Client spend tiers accepted by client_spend_tier. "none" keeps clients
+// with no recorded spend; the others are minimum Buyer.TotalSpent amounts in
+// USD, matching the brackets Upwork shows on client profiles.
+const clientSpendTierNone = "none"
+
+var clientSpendTiers = map[string]float64{
+	"1k":    1_000,
+	"10k":   10_000,
+	"100k+": 100_000,
+}
+
+// clientSpendTierLabels lists the tiers in documentation order.
+var clientSpendTierLabels = []string{clientSpendTierNone, "1k", "10k", "100k+"}
+
+// parseClientSpendTier canonicalizes a tier label. A trailing "+" is
+// optional ("10k+" and "100k" are accepted).
+func parseClientSpendTier(raw string) (string, error) {
+	label := strings.ToLower(strings.TrimSpace(raw))
+	if label == clientSpendTierNone {
+		return label, nil
+	}
+	for _, candidate := range []string{label, strings.TrimSuffix(label, "+"), label + "+"} {
+		if _, ok := clientSpendTiers[candidate]; ok {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("expected one of %s, got %q", strings.Join(clientSpendTierLabels, ", "), raw)
+}
+
+// parseStaleness parses a staleness window such as "72h" or "30d".
+// "0", "off" and "none" disable the cutoff.
+func parseStaleness(raw string) (time.Duration, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	switch normalized {
+	case "0", "off", "none":
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(normalized, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("expected a duration like 72h or 30d, got %q", raw)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(normalized)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("expected a duration like 72h or 30d, got %q", raw)
+	}
+	return d, nil
+}
+
+// searchValuesConflict reports whether two search strings differ beyond case
+// and whitespace.
+func searchValuesConflict(a, b string) bool {
+	return !strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
 func parseCSV(raw string) []string {
 	tokens := strings.Split(raw, ",")
 	result := make([]string, 0, len(tokens))
@@ -532,6 +963,19 @@ func parseNumericRanges(raw string) ([]NumericRange, error) {
 	return result, nil
 }
 
+// parseInterviewing reads interviewing as a boolean (true means at least one
+// freelancer invited to interview, false none) or as count ranges.
+func parseInterviewing(raw string) ([]IntRange, error) {
+	if parsed, err := parseFlexibleBool(raw); err == nil {
+		zero, one := 0, 1
+		if parsed {
+			return []IntRange{{Min: &one}}, nil
+		}
+		return []IntRange{{Min: &zero, Max: &zero}}, nil
+	}
+	return parseIntRanges(raw)
+}
+
 func parseIntRanges(raw string) ([]IntRange, error) {
 	tokens := strings.Split(raw, ",")
 	result := make([]IntRange, 0, len(tokens))
@@ -628,50 +1072,134 @@ func containsString(list []string, candidate string, caseInsensitive bool) bool
 	return false
 }
 
-func applySortParam(opts *FilterOptions, raw string) {
+// sortValues are the sort modes listed when a sort value is rejected. Aliases
+// and Upwork's labels (recency, relevance+desc, ...) are accepted as well.
+var sortValues = []string{
+	"publish_time_asc", "publish_time_desc",
+	"last_visited_asc", "last_visited_desc",
+	"budget_asc", "budget_desc",
+	"created_on_asc", "created_on_desc",
+	"hot",
+	"quality_asc", "quality_desc",
+	"freshness_asc", "freshness_desc",
+}
+
+// applySortParam parses a single sort value or a comma-separated list such
+// as "publish_time_desc,budget_desc". An unknown entry is an error naming the
+// accepted values, and the current sort is kept.
+func applySortParam(opts *FilterOptions, raw string) error {
 	if opts == nil {
-		return
+		return nil
 	}
 
+	var keys []sortKey
+	seen := make(map[sortField]struct{})
+	for _, token := range strings.Split(raw, ",") {
+		if strings.TrimSpace(token) == "" {
+			continue
+		}
+		key, ok := parseSortKey(token)
+		if !ok {
+			return fmt.Errorf("invalid sort parameter %q; accepted values: %s", strings.TrimSpace(token), strings.Join(sortValues, ", "))
+		}
+		if _, dup := seen[key.Field]; dup {
+			continue
+		}
+		seen[key.Field] = struct{}{}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	opts.SortField = keys[0].Field
+	opts.SortAscending = keys[0].Ascending
+	opts.SortKeys = keys
+	return nil
+}
+
+// parseSortKey maps a single sort value, including Upwork's labels, to a sortKey.
+func parseSortKey(raw string) (sortKey, bool) {
 	normalized := strings.ToLower(strings.TrimSpace(raw))
 	normalized = strings.ReplaceAll(normalized, " ", "")
 
 	switch normalized {
 	case "relevance+desc", "relevancedesc", "relevance", "recency", "recency+desc", "recencydesc":
-		opts.SortField = SortPublishTime
-		opts.SortAscending = false
-		return
+		return sortKey{Field: SortPublishTime}, true
 	case "relevance+asc", "relevanceasc", "recency+asc", "recencyasc":
-		opts.SortField = SortPublishTime
-		opts.SortAscending = true
-		return
+		return sortKey{Field: SortPublishTime, Ascending: true}, true
 	case "publish_time_asc", "posted_on_asc":
-		opts.SortField = SortPublishTime
-		opts.SortAscending = true
-		return
+		return sortKey{Field: SortPublishTime, Ascending: true}, true
 	case "publish_time_desc", "posted_on_desc":
-		opts.SortField = SortPublishTime
-		opts.SortAscending = false
-		return
+		return sortKey{Field: SortPublishTime}, true
 	case "last_visited_asc":
-		opts.SortField = SortLastVisited
-		opts.SortAscending = true
-		return
+		return sortKey{Field: SortLastVisited, Ascending: true}, true
 	case "last_visited_desc":
-		opts.SortField = SortLastVisited
-		opts.SortAscending = false
-		return
+		return sortKey{Field: SortLastVisited}, true
+	case "created_on_asc":
+		return sortKey{Field: SortCreatedOn, Ascending: true}, true
+	case "created_on_desc":
+		return sortKey{Field: SortCreatedOn}, true
+	case "hot", "hot_desc":
+		return sortKey{Field: SortHot}, true
+	case "quality_asc":
+		return sortKey{Field: SortQuality, Ascending: true}, true
+	case "quality_desc", "quality":
+		return sortKey{Field: SortQuality}, true
+	case "freshness_asc":
+		return sortKey{Field: SortFreshness, Ascending: true}, true
+	case "freshness_desc", "freshness":
+		return sortKey{Field: SortFreshness}, true
 	case "budget_asc":
-		opts.SortField = SortBudget
-		opts.SortAscending = true
-		return
+		return sortKey{Field: SortBudget, Ascending: true}, true
 	case "budget_desc":
-		opts.SortField = SortBudget
-		opts.SortAscending = false
-		return
+		return sortKey{Field: SortBudget}, true
 	}
 
-	if mapped := parseUpworkSort(raw); mapped != "" && !strings.EqualFold(mapped, raw) {
-		applySortParam(opts, mapped)
+	if mapped := parseUpworkSort(raw); mapped != "" && !strings.EqualFold(mapped, strings.TrimSpace(raw)) {
+		return parseSortKey(mapped)
+	}
+	// Upwork URLs append the direction to the label (client_rating+desc)
+	if label, ascending, ok := cutUpworkSortDirection(normalized); ok {
+		if mapped, known := upworkSortModes[label]; known {
+			key, _ := parseSortKey(mapped)
+			key.Ascending = ascending
+			return key, true
+		}
+	}
+	return sortKey{}, false
+}
+
+// sortKeys returns the ordered sort keys, falling back to SortField and
+// SortAscending when no list was parsed.
+func (opts FilterOptions) sortKeys() []sortKey {
+	if len(opts.SortKeys) > 0 {
+		return opts.SortKeys
+	}
+	return []sortKey{{Field: opts.SortField, Ascending: opts.SortAscending}}
+}
+
+// hasSortField reports whether any sort key uses field.
+func (opts FilterOptions) hasSortField(field sortField) bool {
+	for _, key := range opts.sortKeys() {
+		if key.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// label renders the key in the sort parameter syntax, e.g. "budget_desc".
+func (k sortKey) label() string {
+	field := k.Field
+	if field == "" {
+		field = SortLastVisited
+	}
+	if field == SortHot {
+		return string(SortHot)
+	}
+	if k.Ascending {
+		return string(field) + "_asc"
 	}
+	return string(field) + "_desc"
 }
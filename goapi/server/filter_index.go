@@ -0,0 +1,235 @@
+package server
+
+import (
+	"sort"
+	"strings"
+)
+
+// minIndexedBatch is the batch size below which building an index costs more
+// than scanning every job.
+const minIndexedBatch = 64
+
+// useJobIndex reports whether indexing pays off for opts. Location matching
+// lower-cases and compares every country and timezone per job, so set lookups
+// win there; job type, tier and payment checks alone are cheaper to scan
+// (see BenchmarkFilter*), so they only narrow an index built for location.
+func useJobIndex(batchSize int, opts FilterOptions) bool {
+	return batchSize >= minIndexedBatch && len(opts.LocationRegions) > 0
+}
+
+// jobIndex maps the values the cheapest filters test to the batch positions
+// holding them, so a batch can be narrowed by set lookups before the full
+// applyFilters pass. Only the indexes the query filters on are built.
+type jobIndex struct {
+	jobTypes        map[int][]int
+	contractorTiers map[int][]int
+	paymentVerified map[bool][]int
+	countries       map[string][]int
+	timezones       map[string][]int
+}
+
+func newJobIndex(batch []JobRecord, opts FilterOptions) *jobIndex {
+	idx := &jobIndex{}
+	if len(opts.JobTypeCodes) > 0 {
+		idx.jobTypes = make(map[int][]int)
+	}
+	if len(opts.ContractorTierCodes) > 0 {
+		idx.contractorTiers = make(map[int][]int)
+	}
+	if opts.PaymentVerified != nil {
+		idx.paymentVerified = make(map[bool][]int)
+	}
+	if len(opts.LocationRegions) > 0 {
+		idx.countries = make(map[string][]int)
+		idx.timezones = make(map[string][]int)
+	}
+
+	for i := range batch {
+		job := &batch[i]
+		if idx.jobTypes != nil && job.JobType != nil {
+			idx.jobTypes[*job.JobType] = append(idx.jobTypes[*job.JobType], i)
+		}
+		if idx.contractorTiers != nil && job.ContractorTier != nil {
+			idx.contractorTiers[*job.ContractorTier] = append(idx.contractorTiers[*job.ContractorTier], i)
+		}
+		if idx.paymentVerified != nil && job.Buyer != nil && job.Buyer.PaymentVerified != nil {
+			idx.paymentVerified[*job.Buyer.PaymentVerified] = append(idx.paymentVerified[*job.Buyer.PaymentVerified], i)
+		}
+		if idx.countries != nil {
+			if job.Location != nil {
+				idx.addLocation(job.Location.Country, job.Location.Timezone, i)
+			}
+			if job.Buyer != nil {
+				idx.addLocation(job.Buyer.Country, job.Buyer.Timezone, i)
+			}
+		}
+	}
+	return idx
+}
+
+func (idx *jobIndex) addLocation(country, timezone string, i int) {
+	if country != "" {
+		key := strings.ToLower(country)
+		idx.countries[key] = appendUnique(idx.countries[key], i)
+		if canonical := canonicalCountry(country); canonical != key {
+			idx.countries[canonical] = appendUnique(idx.countries[canonical], i)
+		}
+	}
+	if timezone != "" {
+		key := strings.ToLower(timezone)
+		idx.timezones[key] = appendUnique(idx.timezones[key], i)
+	}
+}
+
+// candidates returns the ascending batch positions that can still pass opts,
+// or nil when no indexed filter applies. It only prunes jobs applyFilters
+// would reject, so the caller must still run applyFilters on each candidate.
+func (idx *jobIndex) candidates(opts FilterOptions) []int {
+	var sets [][]int
+
+	if len(opts.JobTypeCodes) > 0 {
+		sets = append(sets, idx.unionInts(idx.jobTypes, opts.JobTypeCodes))
+	}
+	if len(opts.ContractorTierCodes) > 0 {
+		sets = append(sets, idx.unionInts(idx.contractorTiers, opts.ContractorTierCodes))
+	}
+	if opts.PaymentVerified != nil {
+		sets = append(sets, idx.paymentVerified[*opts.PaymentVerified])
+	}
+	if len(opts.LocationRegions) > 0 {
+		if set, ok := idx.locationCandidates(opts.LocationRegions); ok {
+			sets = append(sets, set)
+		}
+	}
+
+	if len(sets) == 0 {
+		return nil
+	}
+	result := sets[0]
+	for _, set := range sets[1:] {
+		result = intersectSorted(result, set)
+	}
+	if result == nil {
+		result = []int{}
+	}
+	return result
+}
+
+// locationCandidates mirrors matchSingleLocation: a country match (by name or
+// code) or a timezone equal to / containing the filter. Region names match by
+// timezone prefix or country lists, so they disable pruning.
+func (idx *jobIndex) locationCandidates(filters []string) ([]int, bool) {
+	var union []int
+	for _, filter := range filters {
+		normalized := strings.ToLower(strings.TrimSpace(filter))
+		switch normalized {
+		case "", "africa", "europe", "caribbean":
+			return nil, false
+		}
+		if _, ok := countryGroupSets[normalized]; ok {
+			return nil, false
+		}
+		union = append(union, idx.countries[normalized]...)
+		if canonical := canonicalCountry(normalized); canonical != normalized {
+			union = append(union, idx.countries[canonical]...)
+		}
+		for tz, positions := range idx.timezones {
+			if strings.Contains(tz, normalized) {
+				union = append(union, positions...)
+			}
+		}
+	}
+	return sortedUnique(union), true
+}
+
+func (idx *jobIndex) unionInts(index map[int][]int, keys []int) []int {
+	var union []int
+	for _, key := range keys {
+		union = append(union, index[key]...)
+	}
+	return sortedUnique(union)
+}
+
+// filterBatch appends the jobs in batch that pass opts and are not yet in
+// seen to results, preserving batch order. Large batches are narrowed with a
+// jobIndex first; the outcome is identical to scanning every job. A non-nil
+// stats records how many jobs were dropped as duplicates or by filters.
+func filterBatch(batch []JobRecord, opts FilterOptions, seen map[string]struct{}, results []JobRecord, stats *skipStats) []JobRecord {
+	var positions []int
+	if useJobIndex(len(batch), opts) {
+		positions = newJobIndex(batch, opts).candidates(opts)
+	}
+
+	before, duplicates := len(results), 0
+	if stats != nil {
+		// Jobs the index rules out never reach accept, so count them as filtered
+		defer func() {
+			stats.duplicate.Add(int64(duplicates))
+			stats.filteredOut.Add(int64(len(batch) - (len(results) - before) - duplicates))
+		}()
+	}
+
+	accept := func(job *JobRecord) {
+		if _, exists := seen[job.ID]; exists {
+			duplicates++
+			return
+		}
+		if !applyFilters(job, opts) {
+			return
+		}
+		if opts.SearchExpression != nil && !matchesSearchExpression(job, opts.SearchExpression) {
+			return
+		}
+		seen[job.ID] = struct{}{}
+		results = append(results, *job)
+	}
+
+	if positions == nil {
+		for i := range batch {
+			accept(&batch[i])
+		}
+		return results
+	}
+	for _, i := range positions {
+		accept(&batch[i])
+	}
+	return results
+}
+
+func appendUnique(positions []int, i int) []int {
+	if n := len(positions); n > 0 && positions[n-1] == i {
+		return positions
+	}
+	return append(positions, i)
+}
+
+func sortedUnique(values []int) []int {
+	if len(values) == 0 {
+		return []int{}
+	}
+	sort.Ints(values)
+	out := values[:1]
+	for _, v := range values[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func intersectSorted(a, b []int) []int {
+	out := make([]int, 0, len(a))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return out
+}
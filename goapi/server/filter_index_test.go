@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// syntheticBatch builds n jobs cycling through job types, tiers, countries,
+// timezones and payment verification.
+func syntheticBatch(n int) []JobRecord {
+	countries := []string{"US", "DE", "IN", "GB", ""}
+	timezones := []string{"America/New_York", "Europe/Berlin", "Asia/Kolkata", "Europe/London", ""}
+	batch := make([]JobRecord, 0, n)
+	for i := 0; i < n; i++ {
+		jobType := i%2 + 1
+		tier := i%3 + 1
+		verified := i%4 != 0
+		job := JobRecord{
+			ID:             fmt.Sprintf("job-%d", i),
+			Title:          fmt.Sprintf("Job %d", i),
+			JobType:        &jobType,
+			ContractorTier: &tier,
+			Buyer:          &BuyerInfo{PaymentVerified: &verified, Country: countries[i%len(countries)]},
+			Location:       &JobLocation{Timezone: timezones[(i/2)%len(timezones)]},
+		}
+		if i%7 == 0 {
+			job.JobType = nil
+		}
+		batch = append(batch, job)
+	}
+	return batch
+}
+
+// filterLinear is the pre-index filter step, kept as the reference.
+func filterLinear(batch []JobRecord, opts FilterOptions) []JobRecord {
+	seen := map[string]struct{}{}
+	var results []JobRecord
+	for _, rec := range batch {
+		job := rec
+		if _, exists := seen[job.ID]; exists {
+			continue
+		}
+		if !applyFilters(&job, opts) {
+			continue
+		}
+		seen[job.ID] = struct{}{}
+		results = append(results, job)
+	}
+	return results
+}
+
+func indexBenchOptions() []FilterOptions {
+	verified := true
+	return []FilterOptions{
+		{},
+		{JobTypeCodes: []int{1}},
+		{JobTypeCodes: []int{2}, ContractorTierCodes: []int{1, 3}, PaymentVerified: &verified},
+		{LocationRegions: []string{"germany", "de", "new_york"}},
+		{LocationRegions: []string{"europe"}, JobTypeCodes: []int{1}},
+		{LocationRegions: []string{"us"}, ContractorTierCodes: []int{2}},
+		{JobTypeCodes: []int{9}},
+	}
+}
+
+func TestFilterBatchMatchesLinearScan(t *testing.T) {
+	batch := syntheticBatch(500)
+	for i, opts := range indexBenchOptions() {
+		want := filterLinear(batch, opts)
+		got := filterBatch(batch, opts, map[string]struct{}{}, nil, nil)
+		if !reflect.DeepEqual(jobIDs(got), jobIDs(want)) {
+			t.Fatalf("case %d: filterBatch results %v differ from linear %v", i, jobIDs(got), jobIDs(want))
+		}
+
+		// Exercise the index directly even where filterBatch would scan
+		var indexed []JobRecord
+		if positions := newJobIndex(batch, opts).candidates(opts); positions != nil {
+			for _, p := range positions {
+				job := batch[p]
+				if applyFilters(&job, opts) {
+					indexed = append(indexed, job)
+				}
+			}
+		} else {
+			indexed = want
+		}
+		if !reflect.DeepEqual(jobIDs(indexed), jobIDs(want)) {
+			t.Fatalf("case %d: index candidates %v differ from linear %v", i, jobIDs(indexed), jobIDs(want))
+		}
+	}
+}
+
+func TestFilterBatchSkipStats(t *testing.T) {
+	batch := syntheticBatch(500)
+	opts := FilterOptions{JobTypeCodes: []int{1}}
+	seen := map[string]struct{}{batch[0].ID: {}}
+
+	_, stats := withSkipStats(context.Background())
+	got := filterBatch(batch, opts, seen, nil, stats)
+
+	summary := stats.summary()
+	if summary.Duplicate+summary.FilteredOut+int64(len(got)) != int64(len(batch)) {
+		t.Fatalf("skip counts %+v and %d results do not cover %d jobs", summary, len(got), len(batch))
+	}
+	if summary.FilteredOut == 0 || summary.TransformError != 0 {
+		t.Fatalf("unexpected skip counts %+v", summary)
+	}
+}
+
+func benchmarkFilter(b *testing.B, opts FilterOptions, indexed bool) {
+	batch := syntheticBatch(500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if indexed {
+			filterBatch(batch, opts, map[string]struct{}{}, nil, nil)
+		} else {
+			filterLinear(batch, opts)
+		}
+	}
+}
+
+func BenchmarkFilterJobTypeTierLinear(b *testing.B) {
+	benchmarkFilter(b, indexBenchOptions()[2], false)
+}
+
+func BenchmarkFilterJobTypeTierIndexed(b *testing.B) {
+	benchmarkFilter(b, indexBenchOptions()[2], true)
+}
+
+func BenchmarkFilterLocationLinear(b *testing.B) {
+	benchmarkFilter(b, indexBenchOptions()[3], false)
+}
+
+func BenchmarkFilterLocationIndexed(b *testing.B) {
+	benchmarkFilter(b, indexBenchOptions()[3], true)
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseFilterOptionsSuccess(t *testing.T) {
@@ -158,3 +159,739 @@ func TestParseFilterOptionsAppliesLimitClampAndSearchFallback(t *testing.T) {
 		t.Fatalf("unexpected sort configuration: field=%v ascending=%v", opts.SortField, opts.SortAscending)
 	}
 }
+
+func TestParseFilterOptionsRejectsUnknownSort(t *testing.T) {
+	for _, raw := range []string{"banana", "budget_desc,banana"} {
+		_, err := parseFilterOptions(url.Values{"sort": {raw}})
+		if err == nil {
+			t.Fatalf("sort=%s: expected an error", raw)
+		}
+		if !strings.Contains(err.Error(), `"banana"`) || !strings.Contains(err.Error(), "publish_time_desc") {
+			t.Fatalf("sort=%s: expected the value and accepted values in the error, got %v", raw, err)
+		}
+	}
+
+	// Sorts as they appear in pasted Upwork search URLs
+	upworkSorts := []struct {
+		query string
+		want  sortKey
+	}{
+		{"sort=client_rating%2Bdesc", sortKey{Field: SortLastVisited}},
+		{"sort=client_total_charge%2Bdesc", sortKey{Field: SortBudget}},
+		{"sort=client_total_charge+asc", sortKey{Field: SortBudget, Ascending: true}},
+		{"sort=recency", sortKey{Field: SortPublishTime}},
+	}
+	for _, tt := range upworkSorts {
+		derived, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?q=python&" + tt.query)
+		if err != nil {
+			t.Fatalf("%s: unexpected URL error: %v", tt.query, err)
+		}
+		opts, err := parseFilterOptions(derived)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.query, err)
+		}
+		if keys := opts.sortKeys(); len(keys) != 1 || keys[0] != tt.want {
+			t.Fatalf("%s: got sort keys %+v, want %+v", tt.query, keys, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"recency", "posted_on_desc", "budget_desc,"} {
+		if _, err := parseFilterOptions(url.Values{"sort": {raw}}); err != nil {
+			t.Fatalf("sort=%s: unexpected error: %v", raw, err)
+		}
+	}
+}
+
+func TestConfigureDefaultLimit(t *testing.T) {
+	defer func() { defaultLimit = fallbackDefaultLimit }()
+
+	tests := []struct {
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{raw: "35", want: 35},
+		{raw: " 50 ", want: 50},
+		{raw: "51", want: fallbackDefaultLimit, wantErr: true},
+		{raw: "0", want: fallbackDefaultLimit, wantErr: true},
+		{raw: "ten", want: fallbackDefaultLimit, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ConfigureDefaultLimit(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ConfigureDefaultLimit(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if defaultLimit != tt.want {
+			t.Fatalf("ConfigureDefaultLimit(%q) set %d, want %d", tt.raw, defaultLimit, tt.want)
+		}
+		opts, err := parseFilterOptions(url.Values{})
+		if err != nil || opts.Limit != tt.want {
+			t.Fatalf("parseFilterOptions limit = %d (err %v), want %d", opts.Limit, err, tt.want)
+		}
+	}
+}
+
+func TestParseStaleness(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{raw: "72h", want: 72 * time.Hour},
+		{raw: "30d", want: 30 * 24 * time.Hour},
+		{raw: "off", want: 0},
+		{raw: "0", want: 0},
+		{raw: "-5d", wantErr: true},
+		{raw: "week", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := parseStaleness(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("parseStaleness(%q): expected error, got %v", tc.raw, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("parseStaleness(%q) = %v, %v; want %v", tc.raw, got, err, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersMaxStaleness(t *testing.T) {
+	recent := time.Now().Add(-2 * time.Hour)
+	stale := time.Now().Add(-10 * 24 * time.Hour)
+	window := 7 * 24 * time.Hour
+	disabled := time.Duration(0)
+
+	tests := []struct {
+		name      string
+		job       JobRecord
+		staleness *time.Duration
+		want      bool
+	}{
+		{name: "recent job kept", job: JobRecord{ID: "a", LastVisitedAt: &recent}, staleness: &window, want: true},
+		{name: "stale job dropped", job: JobRecord{ID: "b", LastVisitedAt: &stale}, staleness: &window, want: false},
+		{name: "unvisited job kept", job: JobRecord{ID: "c"}, staleness: &window, want: true},
+		{name: "cutoff disabled", job: JobRecord{ID: "d", LastVisitedAt: &stale}, staleness: &disabled, want: true},
+	}
+
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, FilterOptions{MaxStaleness: tc.staleness}); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersClientReviews(t *testing.T) {
+	values := url.Values{}
+	values.Set("client_reviews", "10-")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+	if got := formatFilterOptions(opts); !strings.Contains(got, "client_reviews=10-") {
+		t.Fatalf("expected client_reviews in filter summary, got %q", got)
+	}
+
+	few, many := 3, 25
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "established client", job: JobRecord{Buyer: &BuyerInfo{FeedbackCount: &many}}, want: true},
+		{name: "too few reviews", job: JobRecord{Buyer: &BuyerInfo{FeedbackCount: &few}}, want: false},
+		{name: "unknown feedback count", job: JobRecord{Buyer: &BuyerInfo{}}, want: false},
+		{name: "no buyer", job: JobRecord{}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersCompanySize(t *testing.T) {
+	values := url.Values{}
+	values.Set("company_size", "1-10,1000-")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+	if got := formatFilterOptions(opts); !strings.Contains(got, "company_size=1-10,1000-") {
+		t.Fatalf("expected company_size in filter summary, got %q", got)
+	}
+
+	startup, midsize, enterprise := 5, 200, 5000
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "startup", job: JobRecord{Buyer: &BuyerInfo{CompanySize: &startup}}, want: true},
+		{name: "enterprise", job: JobRecord{Buyer: &BuyerInfo{CompanySize: &enterprise}}, want: true},
+		{name: "mid-size excluded", job: JobRecord{Buyer: &BuyerInfo{CompanySize: &midsize}}, want: false},
+		{name: "unknown size", job: JobRecord{Buyer: &BuyerInfo{}}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersIndustry(t *testing.T) {
+	values := url.Values{}
+	values.Set("industry", " Tech & IT , health-fitness,")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+	if want := []string{"tech & it", "health-fitness"}; !reflect.DeepEqual(opts.Industries, want) {
+		t.Fatalf("unexpected industries: %v", opts.Industries)
+	}
+
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "exact industry", job: JobRecord{Buyer: &BuyerInfo{CompanyIndustry: "Tech & IT"}}, want: true},
+		{name: "normalized industry", job: JobRecord{Buyer: &BuyerInfo{CompanyIndustry: "Health & Fitness"}}, want: true},
+		{name: "other industry", job: JobRecord{Buyer: &BuyerInfo{CompanyIndustry: "Real Estate"}}, want: false},
+		{name: "missing industry", job: JobRecord{Buyer: &BuyerInfo{}}, want: false},
+		{name: "no buyer", job: JobRecord{}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersMinPay(t *testing.T) {
+	values := url.Values{}
+	values.Set("min_pay", "50")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+
+	fixedHigh, fixedLow, rateHigh, rateLow := 500.0, 40.0, 60.0, 30.0
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "fixed budget above", job: JobRecord{Budget: &BudgetInfo{FixedAmount: &fixedHigh}}, want: true},
+		{name: "fixed budget below", job: JobRecord{Budget: &BudgetInfo{FixedAmount: &fixedLow}}, want: false},
+		{name: "hourly max above", job: JobRecord{HourlyInfo: &HourlyBudget{Min: &rateLow, Max: &rateHigh}}, want: true},
+		{name: "hourly max below", job: JobRecord{HourlyInfo: &HourlyBudget{Min: &rateLow, Max: &rateLow}}, want: false},
+		{name: "hourly min only", job: JobRecord{HourlyInfo: &HourlyBudget{Min: &rateHigh}}, want: true},
+		{name: "either side suffices", job: JobRecord{Budget: &BudgetInfo{FixedAmount: &fixedLow}, HourlyInfo: &HourlyBudget{Max: &rateHigh}}, want: true},
+		{name: "no pay info", job: JobRecord{}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	values.Set("min_pay", "-5")
+	if _, err := parseFilterOptions(values); err == nil {
+		t.Fatalf("expected error for negative min_pay")
+	}
+}
+
+func TestApplyFiltersBuyerActiveWithin(t *testing.T) {
+	values := url.Values{}
+	values.Set("buyer_active_within", "7d")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+	if opts.BuyerActiveWithin == nil || *opts.BuyerActiveWithin != 7*24*time.Hour {
+		t.Fatalf("unexpected buyer_active_within: %v", opts.BuyerActiveWithin)
+	}
+
+	activity := func(raw string) *ClientActivity {
+		return buildClientActivity(map[string]interface{}{"lastBuyerActivity": raw})
+	}
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "recently active", job: JobRecord{ClientActivity: activity(time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339))}, want: true},
+		{name: "inactive", job: JobRecord{ClientActivity: activity(time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339))}, want: false},
+		{name: "unparseable", job: JobRecord{ClientActivity: activity("a while ago")}, want: false},
+		{name: "no activity", job: JobRecord{}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersInvitations(t *testing.T) {
+	values := url.Values{}
+	values.Set("invitations", "0-2")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("parseFilterOptions returned error: %v", err)
+	}
+	if got := formatFilterOptions(opts); !strings.Contains(got, "invitations=0-2") {
+		t.Fatalf("expected invitations in filter summary, got %q", got)
+	}
+
+	none, few, many := 0, 2, 15
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "no invites sent", job: JobRecord{ClientActivity: &ClientActivity{InvitationsSent: &none}}, want: true},
+		{name: "many invites sent", job: JobRecord{ClientActivity: &ClientActivity{InvitationsSent: &many, UnansweredInvites: &few}}, want: false},
+		{name: "unanswered fallback", job: JobRecord{ClientActivity: &ClientActivity{UnansweredInvites: &few}}, want: true},
+		{name: "unknown invites", job: JobRecord{ClientActivity: &ClientActivity{}}, want: false},
+		{name: "no activity", job: JobRecord{}, want: false},
+	}
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchesLocationCountryGroups(t *testing.T) {
+	buyerIn := func(country, timezone string) *JobRecord {
+		return &JobRecord{Buyer: &BuyerInfo{Country: country, Timezone: timezone}}
+	}
+
+	tests := []struct {
+		name   string
+		job    *JobRecord
+		filter string
+		want   bool
+	}{
+		{name: "eu member by code", job: buyerIn("DE", "Europe/Berlin"), filter: "eu", want: true},
+		{name: "eu member by name", job: buyerIn("Netherlands", ""), filter: "EU", want: true},
+		{name: "non-eu europe", job: buyerIn("United Kingdom", "Europe/London"), filter: "eu", want: false},
+		{name: "gcc member", job: buyerIn("United Arab Emirates", "Asia/Dubai"), filter: "gcc", want: true},
+		{name: "gcc code", job: buyerIn("SA", ""), filter: "gcc", want: true},
+		{name: "gcc non-member", job: buyerIn("Egypt", "Africa/Cairo"), filter: "gcc", want: false},
+		{name: "asean member", job: buyerIn("Philippines", "Asia/Manila"), filter: "asean", want: true},
+		{name: "asean code", job: buyerIn("VN", ""), filter: "asean", want: true},
+		{name: "asean non-member", job: buyerIn("India", "Asia/Kolkata"), filter: "asean", want: false},
+		{name: "latam member", job: buyerIn("Brazil", "America/Sao_Paulo"), filter: "latam", want: true},
+		{name: "latam spaced name", job: buyerIn("Costa Rica", ""), filter: "latam", want: true},
+		{name: "latam non-member", job: buyerIn("United States", "America/New_York"), filter: "latam", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := matchesLocationFilters(tc.job, []string{tc.filter}); got != tc.want {
+			t.Fatalf("%s: matchesLocationFilters(%q) = %v, want %v", tc.name, tc.filter, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersCountryExclude(t *testing.T) {
+	values := url.Values{}
+	values.Set("country_exclude", "India, pk ,eu")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(opts.ExcludedCountries, []string{"in", "pk", "eu"}) {
+		t.Fatalf("unexpected excluded countries: %v", opts.ExcludedCountries)
+	}
+
+	tests := []struct {
+		name string
+		job  JobRecord
+		want bool
+	}{
+		{name: "excluded by name vs code", job: JobRecord{Buyer: &BuyerInfo{Country: "IN"}}, want: false},
+		{name: "excluded by code vs name", job: JobRecord{Location: &JobLocation{Country: "PAKISTAN"}}, want: false},
+		{name: "excluded group member", job: JobRecord{Buyer: &BuyerInfo{Country: "Germany"}}, want: false},
+		{name: "kept", job: JobRecord{Buyer: &BuyerInfo{Country: "United States"}}, want: true},
+		{name: "unknown country kept", job: JobRecord{}, want: true},
+	}
+
+	for _, tc := range tests {
+		if got := applyFilters(&tc.job, opts); got != tc.want {
+			t.Fatalf("%s: applyFilters = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestUpworkLocationMultiValueIsOR(t *testing.T) {
+	values, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?location=Europe,%20United%20States&q=go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(opts.LocationRegions, []string{"europe", "united states"}) {
+		t.Fatalf("unexpected location regions: %v", opts.LocationRegions)
+	}
+
+	tests := []struct {
+		name string
+		job  *JobRecord
+		want bool
+	}{
+		{name: "europe region by timezone", job: &JobRecord{Buyer: &BuyerInfo{Country: "Germany", Timezone: "Europe/Berlin"}}, want: true},
+		{name: "country by name", job: &JobRecord{Buyer: &BuyerInfo{Country: "United States"}}, want: true},
+		{name: "country by code", job: &JobRecord{Location: &JobLocation{Country: "US"}}, want: true},
+		{name: "neither", job: &JobRecord{Buyer: &BuyerInfo{Country: "India", Timezone: "Asia/Kolkata"}}, want: false},
+	}
+
+	for _, tc := range tests {
+		if got := matchesLocationFilters(tc.job, opts.LocationRegions); got != tc.want {
+			t.Fatalf("%s: matchesLocationFilters = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFiltersHasCategory(t *testing.T) {
+	values := url.Values{}
+	values.Set("has_category", "true")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.HasCategory == nil || !*opts.HasCategory {
+		t.Fatalf("expected has_category=true to be parsed")
+	}
+
+	if !applyFilters(&JobRecord{Category: &CategoryInfo{Name: "Web Development"}}, opts) {
+		t.Fatalf("expected categorized job to pass")
+	}
+	if applyFilters(&JobRecord{}, opts) {
+		t.Fatalf("expected job without category to be excluded")
+	}
+
+	values.Set("has_category", "maybe")
+	if _, err := parseFilterOptions(values); err == nil {
+		t.Fatalf("expected invalid has_category to fail")
+	}
+}
+
+func TestApplyFiltersHasHourlyAndFixed(t *testing.T) {
+	rate, zero, budget := 40.0, 0.0, 500.0
+	hourly := &JobRecord{HourlyInfo: &HourlyBudget{Max: &rate}}
+	fixed := &JobRecord{Budget: &BudgetInfo{FixedAmount: &budget}}
+	placeholder := &JobRecord{HourlyInfo: &HourlyBudget{Min: &zero}, Budget: &BudgetInfo{FixedAmount: &zero}}
+
+	tests := []struct {
+		param, value string
+		job          *JobRecord
+		want         bool
+	}{
+		{"has_hourly", "true", hourly, true},
+		{"has_hourly", "true", fixed, false},
+		{"has_hourly", "true", placeholder, false},
+		{"has_hourly", "false", fixed, true},
+		{"has_fixed", "true", fixed, true},
+		{"has_fixed", "true", hourly, false},
+		{"has_fixed", "true", placeholder, false},
+		{"has_fixed", "false", hourly, true},
+	}
+	for _, tt := range tests {
+		values := url.Values{}
+		values.Set(tt.param, tt.value)
+		opts, err := parseFilterOptions(values)
+		if err != nil {
+			t.Fatalf("%s=%s: unexpected error: %v", tt.param, tt.value, err)
+		}
+		if got := applyFilters(tt.job, opts); got != tt.want {
+			t.Fatalf("%s=%s: applyFilters = %v, want %v", tt.param, tt.value, got, tt.want)
+		}
+	}
+
+	values := url.Values{}
+	values.Set("has_fixed", "maybe")
+	if _, err := parseFilterOptions(values); err == nil {
+		t.Fatalf("expected invalid has_fixed to fail")
+	}
+}
+
+func TestApplyFiltersPublishedAfter(t *testing.T) {
+	cutoff := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	later, earlier := cutoff.Add(time.Minute), cutoff.Add(-time.Minute)
+	opts := FilterOptions{PublishedAfter: &cutoff}
+
+	if !applyFilters(&JobRecord{PublishTime: &later}, opts) {
+		t.Fatalf("expected a later job to pass")
+	}
+	for _, job := range []*JobRecord{{PublishTime: &cutoff}, {PublishTime: &earlier}, {}} {
+		if applyFilters(job, opts) {
+			t.Fatalf("expected job published at %v to be excluded", job.PublishTime)
+		}
+	}
+}
+
+func TestSearchAndQConflict(t *testing.T) {
+	tests := []struct {
+		search, q string
+		wantErr   bool
+	}{
+		{search: "python", q: "", wantErr: false},
+		{search: "", q: "python", wantErr: false},
+		{search: "python  api", q: "Python API", wantErr: false},
+		{search: "python", q: "react", wantErr: true},
+	}
+	for _, tt := range tests {
+		values := url.Values{}
+		if tt.search != "" {
+			values.Set("search", tt.search)
+		}
+		if tt.q != "" {
+			values.Set("q", tt.q)
+		}
+		opts, err := parseFilterOptions(values)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("search=%q q=%q: err = %v, wantErr %v", tt.search, tt.q, err, tt.wantErr)
+		}
+		if !tt.wantErr && opts.SearchQuery == "" {
+			t.Fatalf("search=%q q=%q: expected a search query", tt.search, tt.q)
+		}
+	}
+
+	if _, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?q=python&search=react"); err == nil {
+		t.Fatalf("expected conflicting q and search in upwork_url to fail")
+	}
+	derived, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?q=python&search=python")
+	if err != nil || derived.Get("search") != "python" {
+		t.Fatalf("expected matching q and search to be accepted, got %v, %v", derived, err)
+	}
+}
+
+func TestParseFilterOptionsJobTypeConflict(t *testing.T) {
+	tests := []struct {
+		t, jobType string
+		want       []int
+		wantErr    bool
+	}{
+		{t: "hourly", want: []int{1}},
+		{jobType: "fixed-price", want: []int{2}},
+		{t: "hourly", jobType: "0", want: []int{1}},
+		{t: "hourly,fixed", jobType: "fixed-price,hourly", want: []int{1, 2}},
+		{t: "hourly", jobType: "fixed-price", wantErr: true},
+		{t: "hourly", jobType: "hourly,fixed", wantErr: true},
+	}
+	for _, tt := range tests {
+		values := url.Values{}
+		if tt.t != "" {
+			values.Set("t", tt.t)
+		}
+		if tt.jobType != "" {
+			values.Set("job_type", tt.jobType)
+		}
+		opts, err := parseFilterOptions(values)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("t=%q job_type=%q: err = %v, wantErr %v", tt.t, tt.jobType, err, tt.wantErr)
+		}
+		if !tt.wantErr && !reflect.DeepEqual(opts.JobTypeCodes, tt.want) {
+			t.Fatalf("t=%q job_type=%q: got %v, want %v", tt.t, tt.jobType, opts.JobTypeCodes, tt.want)
+		}
+	}
+
+	if _, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?t=0&job_type=1"); err == nil {
+		t.Fatalf("expected conflicting t and job_type in upwork_url to fail")
+	}
+	if _, err := ParseUpworkSearchURL("https://www.upwork.com/nx/search/jobs/?t=0&job_type=hourly"); err != nil {
+		t.Fatalf("expected matching t and job_type to be accepted, got %v", err)
+	}
+}
+
+func TestApplyFiltersProposalsTierFormats(t *testing.T) {
+	values := url.Values{}
+	values.Set("proposals", "50+")
+	opts, err := parseFilterOptions(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !applyFilters(&JobRecord{ProposalsTier: "50-"}, opts) {
+		t.Fatalf("expected proposals=50+ to match tier 50-")
+	}
+	if !applyFilters(&JobRecord{ProposalsTier: "50+"}, FilterOptions{Proposals: []string{"50-"}}) {
+		t.Fatalf("expected proposals=50- to match tier 50+")
+	}
+	if !applyFilters(&JobRecord{ProposalsTier: "5 - 9"}, FilterOptions{Proposals: []string{"5-9"}}) {
+		t.Fatalf("expected spacing differences to be ignored")
+	}
+	if applyFilters(&JobRecord{ProposalsTier: "20-50"}, opts) || applyFilters(&JobRecord{}, opts) {
+		t.Fatalf("expected other or missing tiers to be excluded")
+	}
+}
+
+func TestApplyFiltersClientSpendTier(t *testing.T) {
+	spent := func(amount float64) *JobRecord {
+		return &JobRecord{Buyer: &BuyerInfo{TotalSpent: &amount}}
+	}
+
+	tests := []struct {
+		raw   string
+		match []*JobRecord
+		skip  []*JobRecord
+	}{
+		{"none", []*JobRecord{{}, spent(0)}, []*JobRecord{spent(50)}},
+		{"1k", []*JobRecord{spent(1000), spent(25000)}, []*JobRecord{spent(999), {}}},
+		{"10K+", []*JobRecord{spent(10000)}, []*JobRecord{spent(9999.5)}},
+		{"100k", []*JobRecord{spent(150000)}, []*JobRecord{spent(99999)}},
+	}
+	for _, tt := range tests {
+		opts, err := parseFilterOptions(url.Values{"client_spend_tier": {tt.raw}})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.raw, err)
+		}
+		for _, job := range tt.match {
+			if !applyFilters(job, opts) {
+				t.Fatalf("%s: expected %+v to match", tt.raw, job.Buyer)
+			}
+		}
+		for _, job := range tt.skip {
+			if applyFilters(job, opts) {
+				t.Fatalf("%s: expected %+v to be excluded", tt.raw, job.Buyer)
+			}
+		}
+	}
+
+	if _, err := parseFilterOptions(url.Values{"client_spend_tier": {"5k"}}); err == nil {
+		t.Fatalf("expected unknown tier to fail")
+	}
+}
+
+func TestParseFilterOptionsExclude(t *testing.T) {
+	jobs := []JobRecord{
+		{ID: "1", Title: "WordPress theme tweaks"},
+		{ID: "2", Title: "Laravel API", Skills: []string{"PHP"}},
+		{ID: "3", Title: "Python scraper"},
+		{ID: "4", Title: "Python WordPress plugin"},
+	}
+	kept := func(values url.Values) string {
+		t.Helper()
+		opts, err := parseFilterOptions(values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for i := range jobs {
+			if matchesSearchExpression(&jobs[i], opts.SearchExpression) {
+				ids = append(ids, jobs[i].ID)
+			}
+		}
+		return strings.Join(ids, ",")
+	}
+
+	tests := []struct {
+		values url.Values
+		want   string
+	}{
+		{url.Values{"exclude": {"wordpress,php"}}, "3"},
+		{url.Values{"exclude": {"wordpress, php"}, "q": {"python"}}, "3"},
+		{url.Values{"exclude": {"python wordpress"}}, "1,2,3"},
+		{url.Values{"exclude": {"php"}, "q": {"wordpress OR laravel"}}, "1,4"},
+	}
+	for _, tt := range tests {
+		if got := kept(tt.values); got != tt.want {
+			t.Fatalf("%v: kept %q, want %q", tt.values, got, tt.want)
+		}
+	}
+
+	opts, err := parseFilterOptions(url.Values{"exclude": {"wordpress,php"}, "q": {"python"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score := opts.SearchExpression.Relevance(&jobs[2]); score != searchFieldWeights["title"] {
+		t.Fatalf("expected exclude to leave relevance alone, got %v", score)
+	}
+
+	if _, err := parseFilterOptions(url.Values{"exclude": {"\"wordpress"}}); err == nil {
+		t.Fatalf("expected unterminated phrase to fail")
+	}
+}
+
+func TestApplyFiltersRequireTitle(t *testing.T) {
+	titled := &JobRecord{Title: "Go developer"}
+	blank := &JobRecord{Title: "  "}
+
+	opts, err := parseFilterOptions(url.Values{"require_title": {"true"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !applyFilters(titled, opts) || applyFilters(blank, opts) {
+		t.Fatalf("expected require_title=true to drop only the blank title")
+	}
+
+	opts, _ = parseFilterOptions(url.Values{})
+	if !applyFilters(blank, opts) {
+		t.Fatalf("expected blank titles to be kept by default")
+	}
+
+	defer func() { requireTitleDefault = false }()
+	if err := ConfigureRequireTitle("true"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts, _ = parseFilterOptions(url.Values{})
+	if applyFilters(blank, opts) {
+		t.Fatalf("expected REQUIRE_TITLE to change the default")
+	}
+	opts, _ = parseFilterOptions(url.Values{"require_title": {"false"}})
+	if !applyFilters(blank, opts) {
+		t.Fatalf("expected require_title=false to override the default")
+	}
+
+	if err := ConfigureRequireTitle("sometimes"); err == nil {
+		t.Fatalf("expected error for a non-boolean default")
+	}
+}
+
+func TestApplyFiltersInterviewing(t *testing.T) {
+	interviewing := func(count int) *JobRecord {
+		return &JobRecord{ClientActivity: &ClientActivity{TotalInvitedToInterview: &count}}
+	}
+
+	tests := []struct {
+		raw   string
+		match []*JobRecord
+		skip  []*JobRecord
+	}{
+		{"true", []*JobRecord{interviewing(1), interviewing(6)}, []*JobRecord{interviewing(0), {}, {ClientActivity: &ClientActivity{}}}},
+		{"false", []*JobRecord{interviewing(0)}, []*JobRecord{interviewing(2), {}}},
+		{"2-5", []*JobRecord{interviewing(2), interviewing(5)}, []*JobRecord{interviewing(1), interviewing(6)}},
+	}
+	for _, tt := range tests {
+		opts, err := parseFilterOptions(url.Values{"interviewing": {tt.raw}})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.raw, err)
+		}
+		for _, job := range tt.match {
+			if !applyFilters(job, opts) {
+				t.Fatalf("%s: expected %+v to match", tt.raw, job.ClientActivity)
+			}
+		}
+		for _, job := range tt.skip {
+			if applyFilters(job, opts) {
+				t.Fatalf("%s: expected %+v to be excluded", tt.raw, job.ClientActivity)
+			}
+		}
+	}
+
+	opts, _ := parseFilterOptions(url.Values{"interviewing": {"yes"}})
+	if !strings.Contains(formatFilterOptions(opts), "interviewing=1-") {
+		t.Fatalf("expected interviewing in the formatted options, got %s", formatFilterOptions(opts))
+	}
+	if _, err := parseFilterOptions(url.Values{"interviewing": {"some"}}); err == nil {
+		t.Fatalf("expected an invalid value to fail")
+	}
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchIDs caps the number of job IDs accepted by POST /jobs/batch.
+const maxBatchIDs = 50
+
+// handleJobsBatch fetches several jobs by document ID in a single round trip.
+// @Summary Batch job lookup
+// @Description Fetch up to 50 jobs by ID. Duplicate IDs are ignored; IDs without a matching document are listed in `missing`.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param request body JobsBatchRequest true "Job IDs to fetch"
+// @Success 200 {object} JobsBatchResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 413 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /jobs/batch [post]
+func (s *Server) handleJobsBatch(c *gin.Context) {
+	var req JobsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if isBodyTooLarge(err) {
+			respondErrorCode(c, http.StatusRequestEntityTooLarge, ErrCodeBodyTooLarge, "Request body is too large")
+			return
+		}
+		respondError(c, http.StatusBadRequest, "Request body must be JSON of the form {\"ids\": [\"<job id>\", ...]}")
+		return
+	}
+
+	ids := dedupeIDs(req.IDs)
+	if len(ids) == 0 {
+		respondError(c, http.StatusBadRequest, "At least one job ID is required")
+		return
+	}
+	if len(ids) > maxBatchIDs {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Too many job IDs: %d provided, maximum is %d", len(ids), maxBatchIDs))
+		return
+	}
+	for _, id := range ids {
+		// Firestore document IDs cannot contain a slash
+		if strings.Contains(id, "/") {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid job ID '%s'", id))
+			return
+		}
+	}
+
+	found, missing, err := s.getJobsByID(c.Request.Context(), ids)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	data := make(map[string]JobDTO, len(found))
+	for id, job := range found {
+		data[id] = job.ToDTO()
+	}
+
+	log.Printf("📦 Batch lookup: requested=%d, found=%d, missing=%d", len(ids), len(data), len(missing))
+
+	c.JSON(http.StatusOK, JobsBatchResponse{
+		Success:     true,
+		Data:        data,
+		Missing:     missing,
+		Count:       len(data),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// getJobsByID loads the given document IDs with a single GetAll call. IDs
+// whose document is absent or cannot be transformed are returned as missing.
+func (s *Server) getJobsByID(requestCtx context.Context, ids []string) (map[string]JobRecord, []string, error) {
+	ctx, cancel := context.WithTimeout(requestCtx, requestTimeout)
+	defer cancel()
+
+	collection := s.client.Collection(s.collectionName)
+	refs := make([]*firestore.DocumentRef, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, collection.Doc(id))
+	}
+
+	snapshots, err := s.client.GetAll(ctx, refs)
+	if err != nil {
+		if isContextCanceled(err) {
+			return nil, nil, fmt.Errorf("firestore batch lookup cancelled: %w", err)
+		}
+		return nil, nil, fmt.Errorf("firestore batch lookup failed: %w", err)
+	}
+
+	found := make(map[string]JobRecord, len(ids))
+	missing := []string{}
+	for i, snap := range snapshots {
+		id := ids[i]
+		if snap == nil || !snap.Exists() {
+			missing = append(missing, id)
+			continue
+		}
+
+		records, err := transformDocument(snap)
+		if err != nil || len(records) == 0 {
+			log.Printf("Skipping document %s: %v", id, err)
+			missing = append(missing, id)
+			continue
+		}
+
+		found[id] = documentRecord(records, id)
+	}
+
+	return found, missing, nil
+}
+
+// documentRecord prefers the record for the document itself over
+// similar-job fallbacks.
+func documentRecord(records []JobRecord, id string) JobRecord {
+	for _, rec := range records {
+		if rec.ID == id {
+			return rec
+		}
+	}
+	return records[0]
+}
+
+// errJobNotFound is returned by getJobByID when no usable document exists.
+var errJobNotFound = errors.New("job not found")
+
+// handleJobByID returns a single job by document ID.
+// @Summary Get job
+// @Description Fetch one job by ID. Admin-scoped keys may pass `include_raw=true` to attach the original Firestore document for diagnosing transformation gaps.
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job document ID"
+// @Param include_raw query string false "Admin only: attach the raw Firestore document" Enums(true, false) default(false)
+// @Success 200 {object} JobResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 404 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /jobs/{id} [get]
+func (s *Server) handleJobByID(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		respondError(c, http.StatusBadRequest, "A job ID is required")
+		return
+	}
+
+	includeRaw := false
+	if raw := c.Query("include_raw"); raw != "" {
+		parsed, err := parseFlexibleBool(raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "include_raw must be true or false")
+			return
+		}
+		includeRaw = parsed
+	}
+	if includeRaw && !isAdminRequest(c) {
+		respondError(c, http.StatusForbidden, "include_raw requires an admin-scoped API key")
+		return
+	}
+
+	job, raw, err := s.getJobByID(c.Request.Context(), id)
+	if errors.Is(err, errJobNotFound) {
+		respondError(c, http.StatusNotFound, fmt.Sprintf("Job '%s' not found", id))
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := JobResponse{
+		Success:     true,
+		Data:        job.ToDTO(),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	}
+	if includeRaw {
+		response.Raw = raw
+		log.Printf("🔬 Attached raw document for job %s", id)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getJobByID loads and transforms one document, also returning its raw data.
+func (s *Server) getJobByID(requestCtx context.Context, id string) (JobRecord, map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(requestCtx, requestTimeout)
+	defer cancel()
+
+	snap, err := s.client.Collection(s.collectionName).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return JobRecord{}, nil, errJobNotFound
+	}
+	if err != nil {
+		if isContextCanceled(err) {
+			return JobRecord{}, nil, fmt.Errorf("firestore lookup cancelled: %w", err)
+		}
+		return JobRecord{}, nil, fmt.Errorf("firestore lookup failed: %w", err)
+	}
+
+	records, err := transformDocument(snap)
+	if err != nil || len(records) == 0 {
+		log.Printf("Skipping document %s: %v", id, err)
+		return JobRecord{}, nil, errJobNotFound
+	}
+
+	return documentRecord(records, id), snap.Data(), nil
+}
+
+// resolveSinceID restricts opts to jobs published after the referenced job.
+// A reference without a publish time is treated as not found.
+func (s *Server) resolveSinceID(ctx context.Context, opts *FilterOptions, id string) error {
+	ref, _, err := s.getJobByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ref.PublishTime == nil {
+		return errJobNotFound
+	}
+	opts.PublishedAfter = ref.PublishTime
+	return nil
+}
+
+// dedupeIDs trims IDs, drops blanks and removes duplicates preserving order.
+func dedupeIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maintenanceFlagKey holds the toggle so every instance sees it
+	maintenanceFlagKey = "maintenance"
+	// How often instances re-read the toggle from Redis
+	maintenancePollInterval = 5 * time.Second
+	// Retry-After hint sent with maintenance 503s
+	maintenanceRetryAfter = 2 * time.Minute
+
+	defaultMaintenanceMessage = "The API is temporarily down for maintenance. Please retry shortly."
+)
+
+// MaintenanceStatus describes whether requests are being turned away.
+// Source is "env" when MAINTENANCE_MODE forces it, "toggle" otherwise.
+type MaintenanceStatus struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// MaintenanceRequest is the body accepted by PUT /admin/maintenance.
+type MaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Message string `json:"message"`
+}
+
+// maintenanceState combines the MAINTENANCE_MODE setting with the last
+// toggle value read from Redis.
+type maintenanceState struct {
+	mu         sync.RWMutex
+	envEnabled bool
+	envMessage string
+	toggle     MaintenanceStatus
+}
+
+func (m *maintenanceState) snapshot() MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.envEnabled {
+		return MaintenanceStatus{Enabled: true, Message: m.envMessage, Source: "env"}
+	}
+	return m.toggle
+}
+
+func (m *maintenanceState) setToggle(status MaintenanceStatus) {
+	m.mu.Lock()
+	m.toggle = status
+	m.mu.Unlock()
+}
+
+// maintenanceMiddleware answers 503 with a Retry-After hint while
+// maintenance is on. Health checks and the admin-scoped routes stay
+// reachable, since migrations and cache clears are what maintenance is for;
+// requireScope still turns non-admin callers away from those.
+func (s *Server) maintenanceMiddleware() gin.HandlerFunc {
+	retryAfter := strconv.Itoa(int(maintenanceRetryAfter.Seconds()))
+	return func(c *gin.Context) {
+		if path := c.Request.URL.Path; path == "/health" || isAdminRoute(path) {
+			c.Next()
+			return
+		}
+		status := s.maintenance.snapshot()
+		if !status.Enabled {
+			c.Next()
+			return
+		}
+		message := status.Message
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		c.Header("Retry-After", retryAfter)
+		respondErrorCode(c, http.StatusServiceUnavailable, ErrCodeMaintenance, message)
+		c.Abort()
+	}
+}
+
+// adminRoutePrefixes are the route groups gated by requireScope(ScopeAdmin).
+var adminRoutePrefixes = []string{"/admin", "/cache", "/api-keys"}
+
+// isAdminRoute reports whether path belongs to an admin-only route group.
+func isAdminRoute(path string) bool {
+	for _, prefix := range adminRoutePrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// loadMaintenanceToggle refreshes the toggle from Redis. A missing key means
+// maintenance is off.
+func (s *Server) loadMaintenanceToggle(ctx context.Context) error {
+	var status MaintenanceStatus
+	if err := s.redisClient.Get(ctx, maintenanceFlagKey, &status); err != nil {
+		if !errors.Is(err, ErrCacheNotFound) {
+			return err
+		}
+		status = MaintenanceStatus{}
+	}
+	s.maintenance.setToggle(status)
+	return nil
+}
+
+// runMaintenancePoller keeps the toggle in sync with Redis until shutdown,
+// so a toggle on one instance reaches the others within a poll interval.
+func (s *Server) runMaintenancePoller(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.loadMaintenanceToggle(s.rootCtx); err != nil {
+			log.Printf("⚠️ Maintenance flag refresh failed: %v", err)
+		}
+		select {
+		case <-s.rootCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// handleMaintenanceStatus reports the current maintenance state.
+// @Summary Maintenance status
+// @Description Admin only. Reports whether maintenance mode is on and whether MAINTENANCE_MODE or the toggle set it.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} MaintenanceStatus
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /admin/maintenance [get]
+func (s *Server) handleMaintenanceStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, s.maintenance.snapshot())
+}
+
+// handleSetMaintenance turns the maintenance toggle on or off.
+// @Summary Toggle maintenance mode
+// @Description Admin only. While on, every endpoint except /health and the admin-only /admin, /cache and /api-keys routes returns 503 with error_code MAINTENANCE and a Retry-After header. The toggle is stored in Redis and reaches all instances within a few seconds; MAINTENANCE_MODE=true keeps maintenance on regardless.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body MaintenanceRequest true "Toggle state and optional message shown to clients"
+// @Success 200 {object} MaintenanceStatus
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 500 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /admin/maintenance [put]
+func (s *Server) handleSetMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if isBodyTooLarge(err) {
+			respondErrorCode(c, http.StatusRequestEntityTooLarge, ErrCodeBodyTooLarge, "Request body is too large")
+			return
+		}
+		respondError(c, http.StatusBadRequest, "Request body must be JSON of the form {\"enabled\": true, \"message\": \"...\"}")
+		return
+	}
+
+	status := MaintenanceStatus{}
+	if *req.Enabled {
+		now := time.Now().UTC()
+		status = MaintenanceStatus{Enabled: true, Message: strings.TrimSpace(req.Message), Source: "toggle", UpdatedAt: &now}
+	}
+
+	var err error
+	if status.Enabled {
+		// No TTL: maintenance stays on until someone turns it off
+		err = s.redisClient.Set(c.Request.Context(), maintenanceFlagKey, status, 0)
+	} else {
+		err = s.redisClient.Delete(c.Request.Context(), maintenanceFlagKey)
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to store maintenance flag: "+err.Error())
+		return
+	}
+	s.maintenance.setToggle(status)
+
+	log.Printf("🚧 Maintenance toggle set to %v", status.Enabled)
+	c.JSON(http.StatusOK, s.maintenance.snapshot())
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := &Server{}
+	router := gin.New()
+	router.Use(srv.maintenanceMiddleware())
+	for _, path := range []string{"/health", "/jobs", "/jobs/123", "/admin/maintenance", "/admin/config", "/admin/migrate/flatten", "/cache/clear", "/api-keys", "/api-keys-export"} {
+		router.GET(path, func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	if rec := get("/jobs"); rec.Code != http.StatusOK {
+		t.Fatalf("expected requests to pass while maintenance is off, got %d", rec.Code)
+	}
+
+	srv.maintenance.setToggle(MaintenanceStatus{Enabled: true, Message: "Migrating", Source: "toggle"})
+	rec := get("/jobs")
+	var resp JobsResponse
+	if rec.Code != http.StatusServiceUnavailable || json.Unmarshal(rec.Body.Bytes(), &resp) != nil {
+		t.Fatalf("expected 503, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.ErrorCode != ErrCodeMaintenance || resp.Message != "Migrating" || rec.Header().Get("Retry-After") != "120" {
+		t.Fatalf("unexpected maintenance response: %+v (Retry-After %q)", resp, rec.Header().Get("Retry-After"))
+	}
+	// Admin routes stay up so operators can run what maintenance is for
+	for _, path := range []string{"/health", "/admin/maintenance", "/admin/config", "/admin/migrate/flatten", "/cache/clear", "/api-keys"} {
+		if rec := get(path); rec.Code != http.StatusOK {
+			t.Fatalf("expected %s to stay reachable, got %d", path, rec.Code)
+		}
+	}
+	for _, path := range []string{"/jobs/123", "/api-keys-export"} {
+		if rec := get(path); rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected %s to be blocked, got %d", path, rec.Code)
+		}
+	}
+
+	// MAINTENANCE_MODE wins over a toggle that was switched off
+	srv.maintenance.setToggle(MaintenanceStatus{})
+	srv.maintenance.envEnabled = true
+	if rec := get("/jobs"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected MAINTENANCE_MODE to force 503, got %d", rec.Code)
+	}
+	if status := srv.maintenance.snapshot(); status.Source != "env" {
+		t.Fatalf("expected env source, got %+v", status)
+	}
+}
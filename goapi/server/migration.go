@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// DefaultFlattenLimit is the number of documents processed per migration run
+	DefaultFlattenLimit = 200
+	maxFlattenLimit     = 5000
+	flattenBatchSize    = 500 // Firestore batch limit
+)
+
+// FlattenStats summarises a flatten migration run.
+type FlattenStats struct {
+	Processed int `json:"processed"`
+	Updated   int `json:"updated"`
+	Skipped   int `json:"skipped"`
+	Errors    int `json:"errors"`
+}
+
+// FlattenJobFields returns the root-level sortable fields (publishTime,
+// budgetAmount, ...) to merge into a job document, or nil when the document
+// is already migrated or carries no job payload.
+func FlattenJobFields(data map[string]interface{}) map[string]interface{} {
+	// Skip if already migrated (has publishTime at root)
+	if _, exists := data["publishTime"]; exists {
+		return nil
+	}
+
+	jobObj := firstNonNilMap(
+		getMap(data, "state", "jobDetails", "job"),
+		getMap(data, "state", "job", "job"),
+	)
+	if jobObj == nil {
+		return nil
+	}
+
+	updates := make(map[string]interface{})
+
+	// Flatten publishTime (most critical for sorting), postedOn and createdOn
+	for _, key := range []string{"publishTime", "postedOn", "createdOn"} {
+		if value, ok := jobObj[key]; ok && value != nil {
+			updates[key] = value
+		}
+	}
+
+	// Flatten budget amount
+	if amount, ok := dig(jobObj, "budget", "amount"); ok && amount != nil {
+		updates["budgetAmount"] = amount
+	}
+
+	// Flatten fixed amount (alternative budget field)
+	if fixedAmt, ok := dig(jobObj, "amount", "amount"); ok && fixedAmt != nil {
+		updates["fixedAmount"] = fixedAmt
+	}
+
+	// Flatten hourly budget
+	for _, key := range []string{"hourlyBudgetMax", "hourlyBudgetMin"} {
+		if value, ok := jobObj[key]; ok && value != nil {
+			updates[key] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+	return updates
+}
+
+// MigrateFlattenFields flattens up to limit documents of collectionName.
+// It is safe to run repeatedly; migrated documents are skipped. progress,
+// when non-nil, is called after every processed document.
+func MigrateFlattenFields(ctx context.Context, client *firestore.Client, collectionName string, limit int, progress func(FlattenStats)) (FlattenStats, error) {
+	var stats FlattenStats
+
+	iter := client.Collection(collectionName).Limit(limit).Documents(ctx)
+	defer iter.Stop()
+
+	batch := client.Batch()
+	batchSize := 0
+
+	commit := func(final bool) {
+		if _, err := batch.Commit(ctx); err != nil {
+			log.Printf("❌ Failed to commit batch: %v", err)
+			stats.Errors += batchSize
+			stats.Updated -= batchSize
+		} else if final {
+			log.Printf("✅ Committed final batch of %d documents", batchSize)
+		} else {
+			log.Printf("✅ Committed batch of %d documents", batchSize)
+		}
+		batch = client.Batch()
+		batchSize = 0
+	}
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return stats, fmt.Errorf("migration cancelled: %w", err)
+			}
+			log.Printf("❌ Error reading document: %v", err)
+			stats.Errors++
+			// A failed iterator does not recover
+			break
+		}
+		stats.Processed++
+
+		updates := FlattenJobFields(doc.Data())
+		if updates == nil {
+			stats.Skipped++
+		} else {
+			batch.Set(doc.Ref, updates, firestore.MergeAll)
+			batchSize++
+			stats.Updated++
+
+			if batchSize >= flattenBatchSize {
+				commit(false)
+			}
+		}
+
+		if progress != nil {
+			progress(stats)
+		}
+		if updates != nil && stats.Updated%10 == 0 {
+			log.Printf("📊 Progress: %d updated, %d skipped, %d errors", stats.Updated, stats.Skipped, stats.Errors)
+		}
+	}
+
+	if batchSize > 0 {
+		commit(true)
+	}
+	if progress != nil {
+		progress(stats)
+	}
+
+	log.Printf("📊 Final stats: %d updated, %d skipped, %d errors", stats.Updated, stats.Skipped, stats.Errors)
+	if stats.Processed == limit {
+		log.Printf("ℹ️  Note: Processed %d docs limit. Run again to continue migration.", limit)
+	}
+
+	return stats, nil
+}
+
+// MigrationStatus reports the state of the most recent remote migration.
+type MigrationStatus struct {
+	Running    bool         `json:"running"`
+	Limit      int          `json:"limit,omitempty"`
+	StartedAt  *time.Time   `json:"started_at,omitempty"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	Stats      FlattenStats `json:"stats"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// migrationRunner guards against concurrent flatten migrations.
+type migrationRunner struct {
+	mu     sync.Mutex
+	status MigrationStatus
+}
+
+func (m *migrationRunner) snapshot() MigrationStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// start marks a migration as running; it returns false if one already is.
+func (m *migrationRunner) start(limit int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.status.Running {
+		return false
+	}
+	now := time.Now().UTC()
+	m.status = MigrationStatus{Running: true, Limit: limit, StartedAt: &now}
+	return true
+}
+
+func (m *migrationRunner) update(stats FlattenStats) {
+	m.mu.Lock()
+	m.status.Stats = stats
+	m.mu.Unlock()
+}
+
+func (m *migrationRunner) finish(stats FlattenStats, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now().UTC()
+	m.status.Running = false
+	m.status.FinishedAt = &now
+	m.status.Stats = stats
+	if err != nil {
+		m.status.Error = err.Error()
+	}
+}
+
+// handleStartFlattenMigration starts the flatten migration in the background.
+// @Summary Start flatten migration
+// @Description Admin only. Flattens sortable fields to the document root for up to `limit` documents in the background. Only one migration runs at a time.
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Documents to process (default 200, max 5000)"
+// @Success 202 {object} MigrationStatus
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Failure 409 {object} MigrationStatus
+// @Security ApiKeyAuth
+// @Router /admin/migrate/flatten [post]
+func (s *Server) handleStartFlattenMigration(c *gin.Context) {
+	limit := DefaultFlattenLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > maxFlattenLimit {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid limit parameter (must be between 1 and %d)", maxFlattenLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	if !s.migration.start(limit) {
+		c.JSON(http.StatusConflict, s.migration.snapshot())
+		return
+	}
+
+	log.Printf("🚚 Starting flatten migration: collection=%s, limit=%d", s.collectionName, limit)
+	go func() {
+		stats, err := MigrateFlattenFields(s.rootCtx, s.client, s.collectionName, limit, s.migration.update)
+		if err != nil {
+			log.Printf("❌ Flatten migration failed: %v", err)
+		}
+		s.migration.finish(stats, err)
+	}()
+
+	c.JSON(http.StatusAccepted, s.migration.snapshot())
+}
+
+// handleFlattenMigrationStatus reports progress of the flatten migration.
+// @Summary Flatten migration status
+// @Description Admin only. Returns progress of the running or most recent flatten migration.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} MigrationStatus
+// @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /admin/migrate/flatten [get]
+func (s *Server) handleFlattenMigrationStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, s.migration.snapshot())
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenJobFields(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "fixed price job under jobDetails",
+			doc: map[string]interface{}{
+				"state": map[string]interface{}{
+					"jobDetails": map[string]interface{}{
+						"job": map[string]interface{}{
+							"publishTime": "2025-01-10T12:00:00Z",
+							"createdOn":   "2025-01-10T11:00:00Z",
+							"budget":      map[string]interface{}{"amount": 500.0},
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"publishTime":  "2025-01-10T12:00:00Z",
+				"createdOn":    "2025-01-10T11:00:00Z",
+				"budgetAmount": 500.0,
+			},
+		},
+		{
+			name: "hourly job under job.job",
+			doc: map[string]interface{}{
+				"state": map[string]interface{}{
+					"job": map[string]interface{}{
+						"job": map[string]interface{}{
+							"postedOn":        "2025-01-10T12:00:00Z",
+							"hourlyBudgetMin": 20.0,
+							"hourlyBudgetMax": 40.0,
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"postedOn":        "2025-01-10T12:00:00Z",
+				"hourlyBudgetMin": 20.0,
+				"hourlyBudgetMax": 40.0,
+			},
+		},
+		{
+			name: "already migrated",
+			doc: map[string]interface{}{
+				"publishTime": "2025-01-10T12:00:00Z",
+				"state": map[string]interface{}{
+					"jobDetails": map[string]interface{}{"job": map[string]interface{}{"publishTime": "2025-01-10T12:00:00Z"}},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "no job payload",
+			doc:  map[string]interface{}{"state": map[string]interface{}{}},
+			want: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FlattenJobFields(tc.doc)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("FlattenJobFields() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
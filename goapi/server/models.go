@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// ScopeAdmin grants access to operator-only request controls
+const ScopeAdmin = "admin"
+
 // APIKey represents an API key document in Firestore
 // Each API key is stored as a separate document in the api_keys collection
 type APIKey struct {
@@ -16,6 +19,8 @@ type APIKey struct {
 	CreatedAt  time.Time `json:"created_at" firestore:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at" firestore:"updated_at"`
 	IsActive   bool      `json:"is_active" firestore:"is_active"`
+	// Scopes grants extra capabilities such as "admin"
+	Scopes []string `json:"scopes,omitempty" firestore:"scopes,omitempty"`
 	// KeyHash is used as the document ID for fast lookups
 	KeyHash string `json:"key_hash" firestore:"key_hash"`
 }
@@ -30,6 +35,16 @@ func (ak *APIKey) IsValid() bool {
 	return ak.IsActive && !ak.IsExpired()
 }
 
+// HasScope reports whether the API key was granted the given scope
+func (ak *APIKey) HasScope(scope string) bool {
+	for _, granted := range ak.Scopes {
+		if strings.EqualFold(strings.TrimSpace(granted), scope) {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateKeyHash creates a SHA256 hash of the API key for use as document ID
 func (ak *APIKey) GenerateKeyHash() {
 	hash := sha256.Sum256([]byte(ak.Key))
@@ -60,6 +75,9 @@ type APIKeyQueryFilter struct {
 	ExpiryFrom *time.Time `json:"expiry_from,omitempty"`
 	ExpiryTo   *time.Time `json:"expiry_to,omitempty"`
 	Limit      int        `json:"limit,omitempty"`
+	// StartAfter resumes after this document ID (a key hash); it cannot be
+	// combined with the expiry range
+	StartAfter string `json:"start_after,omitempty"`
 }
 
 // HashAPIKey generates a SHA256 hash for an API key string
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// qualityWeights weighs the client signals behind JobRecord.QualityScore.
+// Override with QUALITY_SCORE_WEIGHTS via ConfigureQualityWeights.
+var qualityWeights = map[string]float64{
+	"payment":   0.25,
+	"hires":     0.20,
+	"spend":     0.20,
+	"feedback":  0.20,
+	"hire_rate": 0.15,
+}
+
+const (
+	qualityHiresSaturation = 10     // hires at which the hires signal maxes out
+	qualitySpendSaturation = 100000 // lifetime spend at which the spend signal maxes out
+)
+
+// ConfigureQualityWeights replaces the quality score weights from a list such
+// as "payment=0.4,hires=0.1". Unlisted signals get weight 0; weights are
+// normalized so they need not sum to 1.
+func ConfigureQualityWeights(raw string) error {
+	weights := make(map[string]float64, len(qualityWeights))
+	for name := range qualityWeights {
+		weights[name] = 0
+	}
+	total := 0.0
+	for _, token := range parseCSV(raw) {
+		name, value, ok := strings.Cut(token, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok {
+			return fmt.Errorf("invalid quality weight %q: expected name=value", token)
+		}
+		if _, known := weights[name]; !known {
+			return fmt.Errorf("unknown quality signal %q", name)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return fmt.Errorf("invalid weight for %s: must be a non-negative number", name)
+		}
+		weights[name] = weight
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("at least one quality weight must be positive")
+	}
+	for name := range weights {
+		weights[name] /= total
+	}
+	qualityWeights = weights
+	return nil
+}
+
+// formatQualityWeights renders the active weights, e.g. "feedback=0.2,hires=0.2".
+func formatQualityWeights() string {
+	names := make([]string, 0, len(qualityWeights))
+	for name := range qualityWeights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%g", name, qualityWeights[name]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// qualityScore rates the client from 0 to 100 as a weighted sum of signals,
+// each scaled to 0..1:
+//
+//	payment   = 1 when the payment method is verified
+//	hires     = min(jobs with hires / 10, 1)
+//	spend     = min(log10(1+spent) / log10(1+100000), 1)
+//	feedback  = client rating / 5
+//	hire_rate = jobs with hires / (jobs with hires + open jobs)
+//
+// Missing signals count as 0. Returns nil when there is no buyer data.
+func qualityScore(buyer *BuyerInfo) *float64 {
+	if buyer == nil {
+		return nil
+	}
+
+	signals := map[string]float64{}
+	if buyer.PaymentVerified != nil && *buyer.PaymentVerified {
+		signals["payment"] = 1
+	}
+	if buyer.TotalJobsWithHires != nil {
+		signals["hires"] = math.Min(float64(*buyer.TotalJobsWithHires)/qualityHiresSaturation, 1)
+	}
+	if buyer.TotalSpent != nil && *buyer.TotalSpent > 0 {
+		signals["spend"] = math.Min(math.Log10(1+*buyer.TotalSpent)/math.Log10(1+qualitySpendSaturation), 1)
+	}
+	if buyer.Score != nil {
+		signals["feedback"] = math.Max(0, math.Min(*buyer.Score/5, 1))
+	}
+	if buyer.TotalJobsWithHires != nil {
+		hired := float64(*buyer.TotalJobsWithHires)
+		posted := hired
+		if buyer.OpenJobsCount != nil {
+			posted += float64(*buyer.OpenJobsCount)
+		}
+		if posted > 0 {
+			signals["hire_rate"] = hired / posted
+		}
+	}
+
+	score := 0.0
+	for name, weight := range qualityWeights {
+		score += weight * signals[name]
+	}
+	score = math.Round(score*1000) / 10 // 0-100 with one decimal
+	return &score
+}
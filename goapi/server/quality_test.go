@@ -0,0 +1,64 @@
+package server
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQualityScore(t *testing.T) {
+	verified := true
+	hires, open := 10, 0
+	spent, rating := 100000.0, 5.0
+
+	if qualityScore(nil) != nil {
+		t.Fatalf("expected nil score without buyer data")
+	}
+
+	best := qualityScore(&BuyerInfo{PaymentVerified: &verified, TotalJobsWithHires: &hires, OpenJobsCount: &open, TotalSpent: &spent, Score: &rating})
+	if best == nil || *best != 100 {
+		t.Fatalf("expected a perfect score of 100, got %v", best)
+	}
+
+	empty := qualityScore(&BuyerInfo{})
+	if empty == nil || *empty != 0 {
+		t.Fatalf("expected 0 for a buyer with no signals, got %v", empty)
+	}
+
+	onlyVerified := qualityScore(&BuyerInfo{PaymentVerified: &verified})
+	if onlyVerified == nil || *onlyVerified != 25 {
+		t.Fatalf("expected the payment weight alone (25), got %v", onlyVerified)
+	}
+}
+
+func TestConfigureQualityWeights(t *testing.T) {
+	original := qualityWeights
+	defer func() { qualityWeights = original }()
+
+	if err := ConfigureQualityWeights("payment=3,feedback=1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(qualityWeights["payment"]-0.75) > 1e-9 || qualityWeights["hires"] != 0 {
+		t.Fatalf("weights not normalized: %v", qualityWeights)
+	}
+
+	for _, raw := range []string{"payment", "bogus=1", "payment=-1", "payment=0"} {
+		if err := ConfigureQualityWeights(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestSortJobsByQuality(t *testing.T) {
+	low, high := 20.0, 80.0
+	jobs := []JobRecord{{ID: "none"}, {ID: "low", QualityScore: &low}, {ID: "high", QualityScore: &high}}
+
+	sortJobs(jobs, FilterOptions{SortKeys: []sortKey{{Field: SortQuality}}})
+	if got := jobIDs(jobs); got[0] != "high" || got[1] != "low" || got[2] != "none" {
+		t.Fatalf("unexpected quality_desc order: %v", got)
+	}
+
+	sortJobs(jobs, FilterOptions{SortKeys: []sortKey{{Field: SortQuality, Ascending: true}}})
+	if got := jobIDs(jobs); got[0] != "low" || got[1] != "high" || got[2] != "none" {
+		t.Fatalf("unexpected quality_asc order: %v", got)
+	}
+}
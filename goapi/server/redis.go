@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,11 +17,42 @@ import (
 
 // RedisClient wraps the Redis client with common operations
 type RedisClient struct {
-	client *redis.Client
+	client redis.UniversalClient
+	// prefix namespaces every key so environments can share one Redis
+	prefix string
 }
 
+// Redis deployment modes selected by REDIS_MODE
+const (
+	redisModeSingle   = "single"
+	redisModeSentinel = "sentinel"
+	redisModeCluster  = "cluster"
+)
+
 // NewRedisClient creates a new Redis client
 func NewRedisClient() (*RedisClient, error) {
+	rdb, target, err := newUniversalRedisClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", target, err)
+	}
+
+	prefix := os.Getenv("REDIS_KEY_PREFIX")
+	log.Printf("🔴 Redis client connected to %s (key prefix: %q)", target, prefix)
+	return &RedisClient{client: rdb, prefix: prefix}, nil
+}
+
+// newUniversalRedisClient builds a single-node, Sentinel or Cluster client
+// from REDIS_MODE. target describes the connection for logging.
+func newUniversalRedisClient() (rdb redis.UniversalClient, target string, err error) {
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
 		redisAddr = "localhost:6379"
@@ -26,27 +61,145 @@ func NewRedisClient() (*RedisClient, error) {
 	redisPassword := os.Getenv("REDIS_PASSWORD")
 	redisDB := 0 // Default DB
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:         redisAddr,
-		Password:     redisPassword,
-		DB:           redisDB,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 5,
-	})
+	tlsConfig, err := redisTLSConfig()
+	if err != nil {
+		return nil, "", err
+	}
 
-	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("REDIS_MODE")))
+	switch mode {
+	case "", redisModeSingle:
+		rdb, target = redis.NewClient(&redis.Options{
+			Addr:         redisAddr,
+			Password:     redisPassword,
+			DB:           redisDB,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			TLSConfig:    tlsConfig,
+		}), redisAddr
+	case redisModeSentinel:
+		sentinels := parseCSV(os.Getenv("REDIS_SENTINEL_ADDRS"))
+		if len(sentinels) == 0 {
+			return nil, "", fmt.Errorf("REDIS_SENTINEL_ADDRS is required when REDIS_MODE=sentinel")
+		}
+		masterName := strings.TrimSpace(os.Getenv("REDIS_MASTER_NAME"))
+		if masterName == "" {
+			return nil, "", fmt.Errorf("REDIS_MASTER_NAME is required when REDIS_MODE=sentinel")
+		}
+		rdb, target = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       masterName,
+			SentinelAddrs:    sentinels,
+			SentinelPassword: os.Getenv("REDIS_SENTINEL_PASSWORD"),
+			Password:         redisPassword,
+			DB:               redisDB,
+			DialTimeout:      5 * time.Second,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			PoolSize:         10,
+			MinIdleConns:     5,
+			TLSConfig:        tlsConfig,
+		}), fmt.Sprintf("sentinel master %s via %s", masterName, strings.Join(sentinels, ","))
+	case redisModeCluster:
+		// Cluster nodes are the comma-separated REDIS_ADDR entries
+		nodes := parseCSV(redisAddr)
+		rdb, target = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        nodes,
+			Password:     redisPassword,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			TLSConfig:    tlsConfig,
+		}), "cluster "+strings.Join(nodes, ",")
+	default:
+		return nil, "", fmt.Errorf("invalid REDIS_MODE %q: use single, sentinel or cluster", mode)
+	}
 
-	if err := rdb.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	if tlsConfig != nil {
+		target += " over TLS"
+	}
+	return rdb, target, nil
+}
+
+// redisTLSConfig returns the TLS settings for REDIS_TLS=true, or nil when TLS
+// is off. REDIS_TLS_CA_FILE trusts a private CA; REDIS_TLS_CERT_FILE and
+// REDIS_TLS_KEY_FILE add a client certificate for mutual TLS.
+func redisTLSConfig() (*tls.Config, error) {
+	enabled := false
+	if raw := os.Getenv("REDIS_TLS"); raw != "" {
+		var err error
+		if enabled, err = parseFlexibleBool(raw); err != nil {
+			return nil, fmt.Errorf("invalid REDIS_TLS: %w", err)
+		}
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile := os.Getenv("REDIS_TLS_CA_FILE"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read REDIS_TLS_CA_FILE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("REDIS_TLS_CA_FILE contains no PEM certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	certFile, keyFile := os.Getenv("REDIS_TLS_CERT_FILE"), os.Getenv("REDIS_TLS_KEY_FILE")
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("REDIS_TLS_CERT_FILE and REDIS_TLS_KEY_FILE must be set together")
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Redis client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
 	}
 
-	log.Printf("🔴 Redis client connected to %s", redisAddr)
-	return &RedisClient{client: rdb}, nil
+	return config, nil
+}
+
+// key applies the configured namespace to a key name
+func (r *RedisClient) key(name string) string {
+	return r.prefix + name
+}
+
+// deleteMatching deletes every key matching pattern (already prefixed),
+// scanning each master in cluster mode since SCAN is node-local there.
+func (r *RedisClient) deleteMatching(ctx context.Context, pattern string) (int, error) {
+	count := 0
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			if err := client.Del(ctx, iter.Val()).Err(); err != nil {
+				log.Printf("Failed to delete cache key %s: %v", iter.Val(), err)
+			} else {
+				count++
+			}
+		}
+		return iter.Err()
+	}
+
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		var mu sync.Mutex
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return scan(ctx, node)
+		})
+		return count, err
+	}
+	return count, scan(ctx, r.client)
 }
 
 // Close closes the Redis connection
@@ -56,7 +209,7 @@ func (r *RedisClient) Close() error {
 
 // Get retrieves a value from Redis and unmarshals it into the provided interface
 func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) error {
-	val, err := r.client.Get(ctx, key).Result()
+	val, err := r.client.Get(ctx, r.key(key)).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return ErrCacheNotFound
@@ -78,7 +231,7 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, tt
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	if err := r.client.Set(ctx, key, data, ttl).Err(); err != nil {
+	if err := r.client.Set(ctx, r.key(key), data, ttl).Err(); err != nil {
 		return fmt.Errorf("redis set failed: %w", err)
 	}
 
@@ -87,12 +240,12 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, tt
 
 // Delete removes a key from Redis
 func (r *RedisClient) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	return r.client.Del(ctx, r.key(key)).Err()
 }
 
 // Exists checks if a key exists in Redis
 func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
-	count, err := r.client.Exists(ctx, key).Result()
+	count, err := r.client.Exists(ctx, r.key(key)).Result()
 	if err != nil {
 		return false, fmt.Errorf("redis exists failed: %w", err)
 	}
@@ -106,7 +259,7 @@ func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{},
 		return false, fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	result, err := r.client.SetNX(ctx, key, data, ttl).Result()
+	result, err := r.client.SetNX(ctx, r.key(key), data, ttl).Result()
 	if err != nil {
 		return false, fmt.Errorf("redis setnx failed: %w", err)
 	}
@@ -119,7 +272,7 @@ var ErrCacheNotFound = fmt.Errorf("key not found in cache")
 
 // Incr increments a counter in Redis
 func (r *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
-	return r.client.Incr(ctx, key).Result()
+	return r.client.Incr(ctx, r.key(key)).Result()
 }
 
 // GetStats returns cache statistics
@@ -127,8 +280,8 @@ func (r *RedisClient) GetStats(ctx context.Context) (map[string]int64, error) {
 	stats := make(map[string]int64)
 
 	// Get hit/miss counts
-	hits, _ := r.client.Get(ctx, "cache:stats:hits").Int64()
-	misses, _ := r.client.Get(ctx, "cache:stats:misses").Int64()
+	hits, _ := r.client.Get(ctx, r.key("cache:stats:hits")).Int64()
+	misses, _ := r.client.Get(ctx, r.key("cache:stats:misses")).Int64()
 
 	stats["hits"] = hits
 	stats["misses"] = misses
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"testing"
+)
+
+func TestRedisClientKeyPrefix(t *testing.T) {
+	if got := (&RedisClient{}).key("response:jobs:abc"); got != "response:jobs:abc" {
+		t.Fatalf("expected unprefixed key by default, got %q", got)
+	}
+	if got := (&RedisClient{prefix: "staging:"}).key(apiKeyCachePrefix + "hash"); got != "staging:api_key_hash:hash" {
+		t.Fatalf("unexpected prefixed key %q", got)
+	}
+}
+
+func TestNewUniversalRedisClientModes(t *testing.T) {
+	t.Setenv("REDIS_ADDR", "node-a:6379, node-b:6379")
+
+	tests := []struct {
+		mode, sentinels, master string
+		want                    string
+	}{
+		{"", "", "", "*redis.Client"},
+		{"sentinel", "s1:26379,s2:26379", "mymaster", "*redis.Client"},
+		{"cluster", "", "", "*redis.ClusterClient"},
+		{"sentinel", "", "mymaster", ""},
+		{"sentinel", "s1:26379", "", ""},
+		{"replicated", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Setenv("REDIS_MODE", tt.mode)
+		t.Setenv("REDIS_SENTINEL_ADDRS", tt.sentinels)
+		t.Setenv("REDIS_MASTER_NAME", tt.master)
+
+		rdb, target, err := newUniversalRedisClient()
+		if tt.want == "" {
+			if err == nil {
+				rdb.Close()
+				t.Fatalf("mode %q: expected error", tt.mode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("mode %q: unexpected error: %v", tt.mode, err)
+		}
+		if got := fmt.Sprintf("%T", rdb); got != tt.want || target == "" {
+			t.Fatalf("mode %q: got %s (%q), want %s", tt.mode, got, target, tt.want)
+		}
+		rdb.Close()
+	}
+}
+
+func TestRedisTLSConfig(t *testing.T) {
+	t.Setenv("REDIS_TLS", "")
+	if config, err := redisTLSConfig(); err != nil || config != nil {
+		t.Fatalf("expected TLS off by default, got %v, %v", config, err)
+	}
+
+	t.Setenv("REDIS_TLS", "true")
+	config, err := redisTLSConfig()
+	if err != nil || config == nil || config.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("expected TLS config, got %v, %v", config, err)
+	}
+
+	t.Setenv("REDIS_TLS_CERT_FILE", "/tmp/client.pem")
+	if _, err := redisTLSConfig(); err == nil {
+		t.Fatal("expected error for a cert without a key")
+	}
+
+	t.Setenv("REDIS_TLS_CERT_FILE", "")
+	t.Setenv("REDIS_TLS_CA_FILE", "/nonexistent/ca.pem")
+	if _, err := redisTLSConfig(); err == nil {
+		t.Fatal("expected error for a missing CA file")
+	}
+
+	t.Setenv("REDIS_TLS", "maybe")
+	if _, err := redisTLSConfig(); err == nil {
+		t.Fatal("expected error for an invalid REDIS_TLS value")
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"log"
+)
+
+// filterRelaxation clears one filter, reporting whether it was set.
+type filterRelaxation struct {
+	name  string
+	clear func(opts *FilterOptions) bool
+}
+
+// relaxationOrder is the precedence min_results drops filters in, least
+// important first. Search, job type, location, category, has_* and since_id
+// express what the client is looking for and are never relaxed.
+var relaxationOrder = []filterRelaxation{
+	{"buyer_active_within", func(o *FilterOptions) bool {
+		set := o.BuyerActiveWithin != nil
+		o.BuyerActiveWithin = nil
+		return set
+	}},
+	{"client_reviews", func(o *FilterOptions) bool {
+		set := len(o.ClientReviewsRanges) > 0
+		o.ClientReviewsRanges = nil
+		return set
+	}},
+	{"company_size", func(o *FilterOptions) bool {
+		set := len(o.CompanySizeRanges) > 0
+		o.CompanySizeRanges = nil
+		return set
+	}},
+	{"invitations", func(o *FilterOptions) bool {
+		set := len(o.InvitationsRanges) > 0
+		o.InvitationsRanges = nil
+		return set
+	}},
+	{"interviewing", func(o *FilterOptions) bool {
+		set := len(o.InterviewingRanges) > 0
+		o.InterviewingRanges = nil
+		return set
+	}},
+	{"proposals", func(o *FilterOptions) bool {
+		set := len(o.Proposals) > 0
+		o.Proposals = nil
+		return set
+	}},
+	{"previous_clients", func(o *FilterOptions) bool {
+		set := o.PreviousClients != ""
+		o.PreviousClients = ""
+		return set
+	}},
+	{"client_spend_tier", func(o *FilterOptions) bool {
+		set := o.ClientSpendTier != ""
+		o.ClientSpendTier = ""
+		return set
+	}},
+	{"client_hires", func(o *FilterOptions) bool {
+		set := len(o.ClientHiresRanges) > 0
+		o.ClientHiresRanges = nil
+		return set
+	}},
+	{"industry", func(o *FilterOptions) bool {
+		set := len(o.Industries) > 0
+		o.Industries = nil
+		return set
+	}},
+	{"timezone", func(o *FilterOptions) bool {
+		set := len(o.Timezones) > 0
+		o.Timezones = nil
+		return set
+	}},
+	{"duration_v3", func(o *FilterOptions) bool {
+		set := len(o.DurationLabels) > 0
+		o.DurationLabels = nil
+		return set
+	}},
+	{"workload", func(o *FilterOptions) bool {
+		set := len(o.WorkloadValues) > 0
+		o.WorkloadValues = nil
+		return set
+	}},
+	{"contract_to_hire", func(o *FilterOptions) bool {
+		set := o.ContractToHire != nil
+		o.ContractToHire = nil
+		return set
+	}},
+	{"contractor_tier", func(o *FilterOptions) bool {
+		set := len(o.ContractorTierCodes) > 0
+		o.ContractorTierCodes = nil
+		return set
+	}},
+	{"min_pay", func(o *FilterOptions) bool {
+		set := o.MinPay != nil
+		o.MinPay = nil
+		return set
+	}},
+	{"hourly_rate", func(o *FilterOptions) bool {
+		set := len(o.HourlyRanges) > 0
+		o.HourlyRanges = nil
+		return set
+	}},
+	{"amount", func(o *FilterOptions) bool {
+		set := len(o.BudgetRanges) > 0
+		o.BudgetRanges = nil
+		return set
+	}},
+	{"payment_verified", func(o *FilterOptions) bool {
+		set := o.PaymentVerified != nil
+		o.PaymentVerified = nil
+		return set
+	}},
+}
+
+// queryJobsRelaxed runs queryJobs and, while fewer than opts.MinResults jobs
+// match, drops the next set filter in relaxationOrder and queries again.
+// relaxed lists the dropped filters in the order they were removed.
+func (s *Server) queryJobsRelaxed(ctx context.Context, opts FilterOptions) ([]JobRecord, bool, []string, error) {
+	jobs, partial, err := s.queryJobs(ctx, opts)
+	if err != nil || opts.MinResults <= 0 {
+		return jobs, partial, nil, err
+	}
+
+	var relaxed []string
+	for _, relaxation := range relaxationOrder {
+		// A deadline-cut scan says nothing about how many jobs match
+		if len(jobs) >= opts.MinResults || partial {
+			break
+		}
+		if !relaxation.clear(&opts) {
+			continue
+		}
+		relaxed = append(relaxed, relaxation.name)
+
+		jobs, partial, err = s.queryJobs(ctx, opts)
+		if err != nil {
+			return nil, false, relaxed, err
+		}
+	}
+
+	if len(relaxed) > 0 {
+		log.Printf("🪢 Relaxed %v to reach min_results=%d (%d jobs)", relaxed, opts.MinResults, len(jobs))
+	}
+	return jobs, partial, relaxed, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRelaxationOrderClearsFilters(t *testing.T) {
+	verified := true
+	window := 24 * time.Hour
+	opts := FilterOptions{
+		PaymentVerified:     &verified,
+		BuyerActiveWithin:   &window,
+		ClientReviewsRanges: []IntRange{{}},
+		LocationRegions:     []string{"us"},
+	}
+
+	var cleared []string
+	for _, relaxation := range relaxationOrder {
+		if relaxation.clear(&opts) {
+			cleared = append(cleared, relaxation.name)
+		}
+	}
+	if want := []string{"buyer_active_within", "client_reviews", "payment_verified"}; !reflect.DeepEqual(cleared, want) {
+		t.Fatalf("cleared %v, want %v", cleared, want)
+	}
+	if opts.PaymentVerified != nil || opts.BuyerActiveWithin != nil || opts.ClientReviewsRanges != nil {
+		t.Fatalf("expected relaxed filters to be cleared, got %+v", opts)
+	}
+	if len(opts.LocationRegions) != 1 {
+		t.Fatalf("location must never be relaxed")
+	}
+}
+
+func TestParseMinResults(t *testing.T) {
+	values := url.Values{"limit": {"10"}, "min_results": {"5"}}
+	opts, err := parseFilterOptions(values)
+	if err != nil || opts.MinResults != 5 {
+		t.Fatalf("expected min_results=5, got %d, %v", opts.MinResults, err)
+	}
+
+	for _, raw := range []string{"11", "-1", "few"} {
+		values.Set("min_results", raw)
+		if _, err := parseFilterOptions(values); err == nil {
+			t.Fatalf("expected min_results=%s to fail", raw)
+		}
+	}
+}
+
+func TestQueryJobsRelaxedAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Verified", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2, verified: true},
+		{id: "job-b", title: "Unverified", publishTime: base.Add(-2 * time.Hour), budget: 500, jobType: 2},
+	})
+
+	verified := true
+	opts := FilterOptions{Limit: 10, SortField: SortPublishTime, PaymentVerified: &verified, MinResults: 2}
+	jobs, _, relaxed, err := srv.queryJobsRelaxed(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("queryJobsRelaxed failed: %v", err)
+	}
+	if len(jobs) != 2 || !reflect.DeepEqual(relaxed, []string{"payment_verified"}) {
+		t.Fatalf("expected payment_verified to be relaxed to reach 2 jobs, got %v relaxed=%v", jobIDs(jobs), relaxed)
+	}
+
+	opts.MinResults = 1
+	if _, _, relaxed, err := srv.queryJobsRelaxed(context.Background(), opts); err != nil || relaxed != nil {
+		t.Fatalf("expected no relaxation when the minimum is met, got %v, %v", relaxed, err)
+	}
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -41,6 +43,7 @@ const (
 type termNode struct {
 	term     string
 	isPhrase bool
+	stem     string // stemmed term when stemming is enabled
 }
 
 type notNode struct {
@@ -56,6 +59,46 @@ type binaryNode struct {
 type searchDocumentIndex struct {
 	text   string
 	tokens map[string]struct{}
+	stems  map[string]struct{} // built on first stemmed lookup
+}
+
+// hasStem reports whether any indexed token stems to stem.
+func (idx *searchDocumentIndex) hasStem(stem string) bool {
+	if idx.stems == nil {
+		idx.stems = make(map[string]struct{}, len(idx.tokens))
+		for token := range idx.tokens {
+			idx.stems[stemWord(token)] = struct{}{}
+		}
+	}
+	_, ok := idx.stems[stem]
+	return ok
+}
+
+// EnableStemming makes plain terms also match words sharing their stem
+// ("develop" matches "developer"). Phrases and wildcards stay exact.
+func (expr *SearchExpression) EnableStemming() {
+	if expr == nil {
+		return
+	}
+	for _, term := range allTerms(expr.root) {
+		if !term.isPhrase && !strings.ContainsAny(term.term, "* ") {
+			term.stem = stemWord(term.term)
+		}
+	}
+}
+
+// allTerms collects every term node, negated or not.
+func allTerms(node searchNode) []*termNode {
+	switch n := node.(type) {
+	case *termNode:
+		return []*termNode{n}
+	case *binaryNode:
+		return append(allTerms(n.left), allTerms(n.right)...)
+	case *notNode:
+		return allTerms(n.child)
+	default:
+		return nil
+	}
 }
 
 func (expr *SearchExpression) Evaluate(idx *searchDocumentIndex) bool {
@@ -74,6 +117,62 @@ func ParseSearchQuery(raw string) (*SearchExpression, error) {
 		return &SearchExpression{}, nil
 	}
 
+	// Stopwords are dropped only when the rest still forms a query; "the OR a"
+	// or "python NOT the" keep their terms rather than failing or matching all
+	if filtered := removeStopwords(tokens); len(filtered) > 0 && len(filtered) < len(tokens) {
+		if expr, err := parseSearchTokens(filtered); err == nil {
+			return expr, nil
+		}
+	}
+
+	return parseSearchTokens(tokens)
+}
+
+// ParseExcludeQuery parses the exclude parameter into an expression matching
+// the jobs to keep. A comma-separated list ("wordpress,php") drops jobs that
+// contain any listed term; anything else is read as a search expression and
+// drops jobs matching it.
+func ParseExcludeQuery(raw string) (*SearchExpression, error) {
+	items := []string{raw}
+	if strings.Contains(raw, ",") {
+		items = parseCSV(raw)
+	}
+
+	var excluded searchNode
+	for _, item := range items {
+		expr, err := ParseSearchQuery(item)
+		if err != nil {
+			return nil, err
+		}
+		if expr.root == nil {
+			continue
+		}
+		if excluded == nil {
+			excluded = expr.root
+		} else {
+			excluded = &binaryNode{op: logicalOr, left: excluded, right: expr.root}
+		}
+	}
+	if excluded == nil {
+		return &SearchExpression{}, nil
+	}
+	return &SearchExpression{root: &notNode{child: excluded}}, nil
+}
+
+// and returns an expression matching documents that match both expr and
+// other. Either side may be nil or empty.
+func (expr *SearchExpression) and(other *SearchExpression) *SearchExpression {
+	if expr == nil || expr.root == nil {
+		return other
+	}
+	if other == nil || other.root == nil {
+		return expr
+	}
+	return &SearchExpression{root: &binaryNode{op: logicalAnd, left: expr.root, right: other.root}}
+}
+
+// parseSearchTokens builds an expression from tokenized search input.
+func parseSearchTokens(tokens []searchToken) (*SearchExpression, error) {
 	tokens = insertImplicitAnd(tokens)
 
 	rpn, err := shuntingYard(tokens)
@@ -373,6 +472,10 @@ func (n *termNode) eval(idx *searchDocumentIndex) bool {
 		return true
 	}
 
+	if n.stem != "" && idx.hasStem(n.stem) {
+		return true
+	}
+
 	return wildcardMatch(idx.text, n.term)
 }
 
@@ -423,15 +526,33 @@ func buildSearchDocumentIndex(job *JobRecord) *searchDocumentIndex {
 		return nil
 	}
 
+	texts := []string{job.Title, job.Description, job.Engagement, job.DurationLabel, job.Workload}
+	texts = append(texts, job.Tags...)
+	texts = append(texts, job.Skills...)
+	texts = append(texts, job.Occupations...)
+
+	if job.Category != nil {
+		texts = append(texts, job.Category.Name, job.Category.Group, job.Category.Slug, job.Category.GroupSlug)
+	}
+
+	if job.Buyer != nil {
+		texts = append(texts, job.Buyer.Country, job.Buyer.City, job.Buyer.CompanyName, job.Buyer.CompanyIndustry)
+	}
+
+	return indexTexts(texts...)
+}
+
+// indexTexts builds a lower-cased search index over the given texts.
+func indexTexts(texts ...string) *searchDocumentIndex {
 	idx := &searchDocumentIndex{
 		tokens: make(map[string]struct{}),
 	}
 	var builder strings.Builder
 
-	addText := func(text string) {
+	for _, text := range texts {
 		text = strings.TrimSpace(text)
 		if text == "" {
-			return
+			continue
 		}
 		lower := strings.ToLower(text)
 		if builder.Len() > 0 {
@@ -445,34 +566,6 @@ func buildSearchDocumentIndex(job *JobRecord) *searchDocumentIndex {
 		}
 	}
 
-	addText(job.Title)
-	addText(job.Description)
-	addText(job.Engagement)
-	addText(job.DurationLabel)
-	addText(job.Workload)
-
-	for _, tag := range job.Tags {
-		addText(tag)
-	}
-	for _, skill := range job.Skills {
-		addText(skill)
-	}
-	for _, occupation := range job.Occupations {
-		addText(occupation)
-	}
-
-	if job.Category != nil {
-		addText(job.Category.Name)
-		addText(job.Category.Group)
-		addText(job.Category.Slug)
-		addText(job.Category.GroupSlug)
-	}
-
-	if job.Buyer != nil {
-		addText(job.Buyer.Country)
-		addText(job.Buyer.City)
-	}
-
 	idx.text = builder.String()
 	return idx
 }
@@ -501,3 +594,88 @@ func matchesSearchExpression(job *JobRecord, expr *SearchExpression) bool {
 	}
 	return expr.Evaluate(idx)
 }
+
+// searchFieldWeights are the relevance points a term earns per field:
+// "skills" covers skills and tags, "description" everything else indexed.
+// Override with SEARCH_FIELD_WEIGHTS via ConfigureSearchFieldWeights.
+var searchFieldWeights = map[string]float64{
+	"title":       3,
+	"skills":      2,
+	"description": 1,
+}
+
+// ConfigureSearchFieldWeights overrides relevance field weights from a list
+// such as "title=5,description=0.5". Unlisted fields keep their weight.
+func ConfigureSearchFieldWeights(raw string) error {
+	weights := make(map[string]float64, len(searchFieldWeights))
+	for name, weight := range searchFieldWeights {
+		weights[name] = weight
+	}
+	for _, token := range parseCSV(raw) {
+		name, value, ok := strings.Cut(token, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok {
+			return fmt.Errorf("invalid field weight %q: expected field=value", token)
+		}
+		if _, known := weights[name]; !known {
+			return fmt.Errorf("unknown search field %q", name)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return fmt.Errorf("invalid weight for %s: must be a non-negative number", name)
+		}
+		weights[name] = weight
+	}
+	searchFieldWeights = weights
+	return nil
+}
+
+// Relevance scores how well job matches the expression. Each positive
+// (non-negated) term adds the highest searchFieldWeights entry among the
+// fields it is found in (by default 3 for the title, 2 for skills or tags and
+// 1 anywhere else). Without positive terms, as with no search or only an
+// exclude, every job scores 1.
+func (expr *SearchExpression) Relevance(job *JobRecord) float64 {
+	if expr == nil || expr.root == nil {
+		return 1
+	}
+	terms := positiveTerms(expr.root)
+	if len(terms) == 0 {
+		return 1
+	}
+	if job == nil {
+		return 0
+	}
+
+	title := indexTexts(job.Title)
+	skills := indexTexts(append(append([]string{}, job.Skills...), job.Tags...)...)
+	all := buildSearchDocumentIndex(job)
+
+	score := 0.0
+	for _, term := range terms {
+		if !term.eval(all) {
+			continue
+		}
+		best := searchFieldWeights["description"]
+		if term.eval(skills) {
+			best = math.Max(best, searchFieldWeights["skills"])
+		}
+		if term.eval(title) {
+			best = math.Max(best, searchFieldWeights["title"])
+		}
+		score += best
+	}
+	return score
+}
+
+// positiveTerms collects the term nodes that are not under a NOT.
+func positiveTerms(node searchNode) []*termNode {
+	switch n := node.(type) {
+	case *termNode:
+		return []*termNode{n}
+	case *binaryNode:
+		return append(positiveTerms(n.left), positiveTerms(n.right)...)
+	default:
+		return nil
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
@@ -24,22 +29,51 @@ import (
 )
 
 const (
-	defaultLimit   = 20
-	maxLimit       = 50
-	requestTimeout = 20 * time.Second
+	fallbackDefaultLimit = 20
+	maxLimit             = 50
+	requestTimeout       = 20 * time.Second
+	// Budget for a replica attempt, leaving time to retry on the primary
+	replicaQueryTimeout = 8 * time.Second
+
+	// Defaults for the query length guard
+	defaultMaxQueryLength = 8192
+	defaultMaxParamLength = 4096
+	// Default cap for POST/PUT/PATCH/DELETE request bodies
+	defaultMaxBodyBytes = 1 << 20
 
 	// Cache TTLs
 	jobsCacheTTL = 5 * time.Second
+	// Upper bound for the admin cache_ttl override
+	maxCacheTTLOverride = time.Hour
+	// Browser cache lifetime for the swagger and openapi routes
+	docsCacheMaxAge = time.Hour
+
+	// Project ID used against the Firestore emulator when none is configured
+	defaultEmulatorProjectID = "demo-upwork-jobs"
 )
 
 type Server struct {
 	rootCtx        context.Context
 	cancelRoot     context.CancelFunc
 	client         *firestore.Client
+	replicaClient  *firestore.Client // Optional read replica; nil when disabled
 	redisClient    *RedisClient
 	apiKeyService  *APIKeyService
 	collectionName string
+	projectFields  bool          // Fetch only jobProjectionPaths from Firestore
+	maxStaleness   time.Duration // Default LastVisitedAt cutoff; 0 disables
+	maxQueryLength int           // Longest accepted raw query string
+	maxParamLength int           // Longest accepted single parameter value
+	maxBodyBytes   int64         // Largest accepted body on mutating routes
+	features       FeatureFlags  // Runtime toggles from FEATURES
+	migration      migrationRunner
+	maintenance    maintenanceState // MAINTENANCE_MODE plus the Redis toggle
+	skills         skillIndex       // Skill frequencies for /skills/suggest
+	coalescer      jobsCoalescer
 	apiKey         string // Legacy API key for backward compatibility
+
+	// Set by JOBS_CACHE_ENABLED=false to keep /jobs off Redis entirely
+	jobsCacheDisabled bool
 }
 
 // NewServer creates a server with Firestore client and configuration.
@@ -47,15 +81,26 @@ func NewServer() (*Server, error) {
 	apiKey := mustEnv("API_KEY")
 	log.Printf("🔐 Legacy API key loaded (%d chars): %s", len(apiKey), maskAPIKey(apiKey))
 
-	serviceAccountPath := mustEnv("FIREBASE_SERVICE_ACCOUNT_PATH")
-
 	projectID := os.Getenv("FIREBASE_PROJECT_ID")
-	if projectID == "" {
-		var err error
-		projectID, err = loadProjectID(serviceAccountPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to determine Firestore project ID: %w", err)
+	var clientOpts []option.ClientOption
+
+	// Emulator mode: the Firestore client routes to FIRESTORE_EMULATOR_HOST
+	// on its own and needs no credentials.
+	if emulatorHost := os.Getenv("FIRESTORE_EMULATOR_HOST"); emulatorHost != "" {
+		if projectID == "" {
+			projectID = defaultEmulatorProjectID
 		}
+		log.Printf("🧪 Firestore emulator mode enabled: host=%s", emulatorHost)
+	} else {
+		serviceAccountPath := mustEnv("FIREBASE_SERVICE_ACCOUNT_PATH")
+		if projectID == "" {
+			var err error
+			projectID, err = loadProjectID(serviceAccountPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine Firestore project ID: %w", err)
+			}
+		}
+		clientOpts = append(clientOpts, option.WithCredentialsFile(serviceAccountPath))
 	}
 
 	collectionName := os.Getenv("FIRESTORE_COLLECTION")
@@ -63,35 +108,226 @@ func NewServer() (*Server, error) {
 		collectionName = "individual_jobs"
 	}
 
+	if raw := os.Getenv("HOT_SORT_HALF_LIFE"); raw != "" {
+		if err := ConfigureHotHalfLife(raw); err != nil {
+			return nil, fmt.Errorf("invalid HOT_SORT_HALF_LIFE: %w", err)
+		}
+		log.Printf("🔥 Hot sort half-life: %v", hotHalfLife)
+	}
+
+	if raw := os.Getenv("QUALITY_SCORE_WEIGHTS"); raw != "" {
+		if err := ConfigureQualityWeights(raw); err != nil {
+			return nil, fmt.Errorf("invalid QUALITY_SCORE_WEIGHTS: %w", err)
+		}
+		log.Printf("⭐ Quality score weights: %s", formatQualityWeights())
+	}
+
+	if raw := os.Getenv("PRIVACY_STATUS_CODES"); raw != "" {
+		if err := ConfigurePrivacyStatusCodes(raw); err != nil {
+			return nil, fmt.Errorf("invalid PRIVACY_STATUS_CODES: %w", err)
+		}
+		log.Printf("🔒 Privacy status codes: %s", raw)
+	}
+
+	if raw := os.Getenv("BUDGET_CURRENCY_SOURCE"); raw != "" {
+		if err := ConfigureBudgetCurrencySource(raw); err != nil {
+			return nil, fmt.Errorf("invalid BUDGET_CURRENCY_SOURCE: %w", err)
+		}
+		log.Printf("💱 Budget currency source: %s", budgetCurrencySource)
+	}
+
+	if raw := os.Getenv("FUTURE_PUBLISH_WINDOW"); raw != "" {
+		if err := ConfigureFuturePublishWindow(raw); err != nil {
+			return nil, fmt.Errorf("invalid FUTURE_PUBLISH_WINDOW: %w", err)
+		}
+	}
+	if raw := os.Getenv("FUTURE_PUBLISH_ACTION"); raw != "" {
+		if err := ConfigureFuturePublishAction(raw); err != nil {
+			return nil, fmt.Errorf("invalid FUTURE_PUBLISH_ACTION: %w", err)
+		}
+		log.Printf("🕰️ Publish times more than %v ahead: %s", futurePublishWindow, futurePublishAction)
+	}
+
+	if raw := os.Getenv("SEARCH_FIELD_WEIGHTS"); raw != "" {
+		if err := ConfigureSearchFieldWeights(raw); err != nil {
+			return nil, fmt.Errorf("invalid SEARCH_FIELD_WEIGHTS: %w", err)
+		}
+		log.Printf("🔎 Search field weights: %v", searchFieldWeights)
+	}
+
+	if raw, ok := os.LookupEnv("SEARCH_STOPWORDS"); ok {
+		ConfigureSearchStopwords(raw)
+		log.Printf("🔎 Search stopwords: %d configured", len(searchStopwords))
+	}
+
+	var maxStaleness time.Duration
+	if raw := os.Getenv("MAX_JOB_STALENESS"); raw != "" {
+		var err error
+		maxStaleness, err = parseStaleness(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAX_JOB_STALENESS: %w", err)
+		}
+		log.Printf("🕸️ Excluding jobs not visited within %v by default", maxStaleness)
+	}
+
+	if raw := os.Getenv("REQUIRE_TITLE"); raw != "" {
+		if err := ConfigureRequireTitle(raw); err != nil {
+			return nil, fmt.Errorf("invalid REQUIRE_TITLE: %w", err)
+		}
+		if requireTitleDefault {
+			log.Printf("🏷️ Dropping jobs with blank titles unless require_title=false")
+		}
+	}
+
+	if raw := os.Getenv("DEFAULT_LIMIT"); raw != "" {
+		if err := ConfigureDefaultLimit(raw); err != nil {
+			log.Printf("⚠️ Ignoring DEFAULT_LIMIT: %v (using %d)", err, fallbackDefaultLimit)
+		}
+	}
+
+	maxQueryLength, err := envPositiveInt("MAX_QUERY_LENGTH", defaultMaxQueryLength)
+	if err != nil {
+		return nil, err
+	}
+	maxParamLength, err := envPositiveInt("MAX_PARAM_LENGTH", defaultMaxParamLength)
+	if err != nil {
+		return nil, err
+	}
+	maxBodyBytes, err := envPositiveInt("MAX_BODY_BYTES", defaultMaxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	warmURLs, err := parseCacheWarmURLs(os.Getenv("CACHE_WARM_URLS"))
+	if err != nil {
+		return nil, err
+	}
+
+	// "0" disables the sweep
+	expirySweepInterval := defaultExpirySweepInterval
+	if raw := os.Getenv("API_KEY_EXPIRY_SWEEP_INTERVAL"); raw != "" {
+		expirySweepInterval, err = time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || expirySweepInterval < 0 {
+			return nil, fmt.Errorf("invalid API_KEY_EXPIRY_SWEEP_INTERVAL: must be a duration such as 1h, or 0 to disable")
+		}
+	}
+
+	skillRefreshInterval := defaultSkillRefreshInterval
+	if raw := os.Getenv("SKILLS_REFRESH_INTERVAL"); raw != "" {
+		skillRefreshInterval, err = time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || skillRefreshInterval <= 0 {
+			return nil, fmt.Errorf("invalid SKILLS_REFRESH_INTERVAL: must be a positive duration")
+		}
+	}
+
+	warmInterval := defaultCacheWarmInterval
+	if raw := os.Getenv("CACHE_WARM_INTERVAL"); raw != "" {
+		warmInterval, err = time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CACHE_WARM_INTERVAL: must be a positive duration")
+		}
+		if err := validateCacheWarmInterval(warmInterval); err != nil {
+			return nil, fmt.Errorf("invalid CACHE_WARM_INTERVAL: %w", err)
+		}
+	}
+
+	features := ParseFeatureFlags(os.Getenv("FEATURES"))
+	log.Printf("🚩 Feature flags enabled: %v", features.Names())
+
+	projectFields := true
+	if raw := os.Getenv("FIRESTORE_PROJECTION"); raw != "" {
+		enabled, err := parseFlexibleBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FIRESTORE_PROJECTION: %w", err)
+		}
+		projectFields = enabled
+	}
+
+	jobsCacheEnabled := true
+	if raw := os.Getenv("JOBS_CACHE_ENABLED"); raw != "" {
+		enabled, err := parseFlexibleBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JOBS_CACHE_ENABLED: %w", err)
+		}
+		jobsCacheEnabled = enabled
+	}
+
+	maintenanceMode := false
+	if raw := os.Getenv("MAINTENANCE_MODE"); raw != "" {
+		enabled, err := parseFlexibleBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAINTENANCE_MODE: %w", err)
+		}
+		maintenanceMode = enabled
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	client, err := firestore.NewClient(ctx, projectID, option.WithCredentialsFile(serviceAccountPath))
+	client, err := firestore.NewClient(ctx, projectID, clientOpts...)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("failed to create Firestore client: %w", err)
 	}
 
-	log.Printf("🔥 Firestore client initialized: project=%s, collection=%s", projectID, collectionName)
+	log.Printf("🔥 Firestore client initialized: project=%s, collection=%s, projection=%v", projectID, collectionName, projectFields)
+
+	replicaClient, err := newReplicaClient(ctx, projectID, clientOpts)
+	if err != nil {
+		cancel()
+		client.Close()
+		return nil, fmt.Errorf("failed to create Firestore replica client: %w", err)
+	}
 
 	// Initialize Redis client
 	redisClient, err := NewRedisClient()
 	if err != nil {
 		cancel()
 		client.Close()
+		if replicaClient != nil {
+			replicaClient.Close()
+		}
 		return nil, fmt.Errorf("failed to create Redis client: %w", err)
 	}
 
 	// Initialize API key service
 	apiKeyService := NewAPIKeyService(client, redisClient)
 
-	return &Server{
+	srv := &Server{
 		rootCtx:        ctx,
 		cancelRoot:     cancel,
 		client:         client,
+		replicaClient:  replicaClient,
 		redisClient:    redisClient,
 		apiKeyService:  apiKeyService,
 		collectionName: collectionName,
+		projectFields:  projectFields,
+		maxStaleness:   maxStaleness,
+		maxQueryLength: maxQueryLength,
+		maxParamLength: maxParamLength,
+		maxBodyBytes:   int64(maxBodyBytes),
+		features:       features,
 		apiKey:         apiKey,
-	}, nil
+
+		jobsCacheDisabled: !jobsCacheEnabled,
+	}
+	srv.maintenance.envEnabled = maintenanceMode
+	srv.maintenance.envMessage = os.Getenv("MAINTENANCE_MESSAGE")
+	if maintenanceMode {
+		log.Printf("🚧 MAINTENANCE_MODE is on: non-health endpoints return 503")
+	}
+
+	if !jobsCacheEnabled {
+		log.Printf("⏭️ /jobs response cache disabled (JOBS_CACHE_ENABLED=false)")
+	}
+	if len(warmURLs) > 0 && jobsCacheEnabled {
+		go srv.runCacheWarmer(warmURLs, warmInterval)
+	}
+	go srv.runSkillIndexRefresher(skillRefreshInterval)
+	go srv.runMaintenancePoller(maintenancePollInterval)
+	if expirySweepInterval > 0 {
+		go apiKeyService.RunExpirySweep(srv.rootCtx, expirySweepInterval)
+	}
+
+	return srv, nil
 }
 
 // Shutdown releases Firestore and Redis resources.
@@ -104,6 +340,11 @@ func (s *Server) Shutdown() {
 			log.Printf("error closing Redis client: %v", err)
 		}
 	}
+	if s.replicaClient != nil {
+		if err := s.replicaClient.Close(); err != nil {
+			log.Printf("error closing Firestore replica client: %v", err)
+		}
+	}
 	if s.client != nil {
 		if err := s.client.Close(); err != nil {
 			log.Printf("error closing Firestore client: %v", err)
@@ -111,6 +352,36 @@ func (s *Server) Shutdown() {
 	}
 }
 
+// newReplicaClient creates the optional read-replica Firestore client.
+// It returns nil when neither FIRESTORE_REPLICA_PROJECT_ID nor
+// FIRESTORE_REPLICA_DATABASE is set. Credentials default to the primary's.
+func newReplicaClient(ctx context.Context, primaryProjectID string, primaryOpts []option.ClientOption) (*firestore.Client, error) {
+	replicaProjectID := os.Getenv("FIRESTORE_REPLICA_PROJECT_ID")
+	replicaDatabase := os.Getenv("FIRESTORE_REPLICA_DATABASE")
+	if replicaProjectID == "" && replicaDatabase == "" {
+		return nil, nil
+	}
+	if replicaProjectID == "" {
+		replicaProjectID = primaryProjectID
+	}
+	if replicaDatabase == "" {
+		replicaDatabase = firestore.DefaultDatabaseID
+	}
+
+	opts := primaryOpts
+	if path := os.Getenv("FIRESTORE_REPLICA_SERVICE_ACCOUNT_PATH"); path != "" {
+		opts = []option.ClientOption{option.WithCredentialsFile(path)}
+	}
+
+	client, err := firestore.NewClientWithDatabase(ctx, replicaProjectID, replicaDatabase, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🪞 Firestore read replica enabled: project=%s, database=%s", replicaProjectID, replicaDatabase)
+	return client, nil
+}
+
 // Router constructs the Gin router with middleware and routes.
 func (s *Server) Router() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
@@ -118,21 +389,44 @@ func (s *Server) Router() *gin.Engine {
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(s.loggingMiddleware())
+	router.Use(s.maintenanceMiddleware())
+	router.Use(s.queryLengthMiddleware())
+	router.Use(s.bodySizeMiddleware())
 
 	group := router.Group("/")
 	group.Use(s.authMiddleware())
 	group.GET("/health", s.handleHealth)
 	group.GET("/jobs", s.handleJobs)
+	group.HEAD("/jobs", s.handleJobs)
+	group.POST("/jobs/batch", s.handleJobsBatch)
+	group.GET("/jobs/:id", s.handleJobByID)
+	group.GET("/skills/suggest", s.handleSkillsSuggest)
+	group.GET("/upwork-url/parse", s.handleUpworkURLParse)
 
 	// API key management endpoints
-	group.POST("/api-keys/refresh-cache", s.handleRefreshAPIKeysCache)
-	group.DELETE("/api-keys/:key/cache", s.handleClearAPIKeyCache)
+	apiKeys := group.Group("/api-keys", requireScope(ScopeAdmin))
+	apiKeys.GET("", s.handleListAPIKeys)
+	apiKeys.POST("/refresh-cache", s.handleRefreshAPIKeysCache)
+	apiKeys.DELETE("/:key/cache", s.handleClearAPIKeyCache)
+	apiKeys.GET("/audit", s.handleAPIKeyAudit)
 
 	// Cache management endpoints
-	group.GET("/cache/stats", s.handleCacheStats)
-	group.DELETE("/cache/clear", s.handleClearCache)
-
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	cache := group.Group("/cache", requireScope(ScopeAdmin))
+	cache.GET("/stats", s.handleCacheStats)
+	cache.DELETE("/clear", s.handleClearCache)
+
+	// Admin endpoints
+	admin := group.Group("/admin", requireScope(ScopeAdmin))
+	admin.POST("/migrate/flatten", s.handleStartFlattenMigration)
+	admin.GET("/migrate/flatten", s.handleFlattenMigrationStatus)
+	admin.DELETE("/cache/jobs", s.handleClearJobsCache)
+	admin.GET("/config", s.handleAdminConfig)
+	admin.GET("/maintenance", s.handleMaintenanceStatus)
+	admin.PUT("/maintenance", s.handleSetMaintenance)
+
+	docsCache := docsCacheMiddleware(docsCacheMaxAge)
+	router.GET("/swagger/*any", docsCache, ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/openapi.json", docsCache, s.handleOpenAPISpec)
 
 	return router
 }
@@ -147,6 +441,84 @@ func (s *Server) loggingMiddleware() gin.HandlerFunc {
 	}
 }
 
+// docsCacheMiddleware lets browsers cache the docs routes; their content only
+// changes on deploy. Handlers that fail override the header with no-store.
+func docsCacheMiddleware(maxAge time.Duration) gin.HandlerFunc {
+	value := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", value)
+		c.Next()
+	}
+}
+
+// queryLengthMiddleware rejects oversized query strings or parameter values
+// before any parsing work is done.
+func (s *Server) queryLengthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.maxQueryLength > 0 && len(c.Request.URL.RawQuery) > s.maxQueryLength {
+			respondErrorCode(c, http.StatusBadRequest, ErrCodeQueryTooLong,
+				fmt.Sprintf("Query string is %d characters; the maximum is %d", len(c.Request.URL.RawQuery), s.maxQueryLength))
+			c.Abort()
+			return
+		}
+		if name, length := s.oversizedParam(c.Request.URL.Query()); name != "" {
+			respondErrorCode(c, http.StatusBadRequest, ErrCodeQueryTooLong,
+				fmt.Sprintf("Parameter '%s' is %d characters; the maximum is %d", name, length, s.maxParamLength))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// bodySizeMiddleware caps request bodies on mutating methods. Bodies that
+// declare a larger Content-Length are rejected with 413 up front; others are
+// wrapped so reading past the cap fails (see isBodyTooLarge).
+func (s *Server) bodySizeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+		if s.maxBodyBytes <= 0 {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > s.maxBodyBytes {
+			respondErrorCode(c, http.StatusRequestEntityTooLarge, ErrCodeBodyTooLarge,
+				fmt.Sprintf("Request body is %d bytes; the maximum is %d", c.Request.ContentLength, s.maxBodyBytes))
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, s.maxBodyBytes)
+		c.Next()
+	}
+}
+
+// isBodyTooLarge reports whether err came from reading past the body cap.
+func isBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// oversizedParam returns the first parameter whose value exceeds
+// maxParamLength, along with its length.
+func (s *Server) oversizedParam(values url.Values) (string, int) {
+	if s.maxParamLength <= 0 {
+		return "", 0
+	}
+	for name, vals := range values {
+		for _, v := range vals {
+			if len(v) > s.maxParamLength {
+				return name, len(v)
+			}
+		}
+	}
+	return "", 0
+}
+
 // authMiddleware ensures requests include a valid API key.
 func (s *Server) authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -169,6 +541,7 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 		// Fallback to legacy API key for backward compatibility
 		if apiKey == s.apiKey {
 			log.Printf("🔑 Using legacy API key: %s", maskAPIKey(apiKey))
+			c.Set("legacy_api_key", true)
 			c.Next()
 			return
 		}
@@ -180,6 +553,39 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requireScope rejects callers whose API key lacks scope with 403. It must
+// run after authMiddleware, which stores the key on the context.
+func requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasScope(c, scope) {
+			respondError(c, http.StatusForbidden, fmt.Sprintf("This endpoint requires an API key with the '%s' scope", scope))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// hasScope reports whether the caller's API key grants scope. The legacy
+// operator key holds every scope.
+func hasScope(c *gin.Context, scope string) bool {
+	if c.GetBool("legacy_api_key") {
+		return true
+	}
+	if info, ok := c.Get("api_key_info"); ok {
+		if apiKey, ok := info.(*APIKey); ok && apiKey != nil {
+			return apiKey.HasScope(scope)
+		}
+	}
+	return false
+}
+
+// isAdminRequest reports whether the caller holds the admin scope, which
+// unlocks admin-only request controls such as debug and cache_ttl.
+func isAdminRequest(c *gin.Context) bool {
+	return hasScope(c, ScopeAdmin)
+}
+
 // handleHealth is a simple readiness endpoint.
 // @Summary Health check
 // @Description Returns a 200 response when the API is up.
@@ -197,18 +603,61 @@ func (s *Server) handleHealth(c *gin.Context) {
 	})
 }
 
+// handleOpenAPISpec serves the generated Swagger 2.0 (OpenAPI 2) spec so
+// codegen tools can consume it without scraping the Swagger UI.
+// @Summary OpenAPI spec
+// @Description Returns the generated Swagger 2.0 spec as JSON. Does not require an API key.
+// @Tags docs
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} JobsResponse
+// @Router /openapi.json [get]
+func (s *Server) handleOpenAPISpec(c *gin.Context) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		c.Header("Cache-Control", "no-store")
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("OpenAPI spec unavailable: %v", err))
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
+}
+
 // handleJobs queries Firestore with filters and returns normalized job data.
 // @Summary List jobs
-// @Description Retrieve normalized job documents with optional filters.
+// @Description Retrieve normalized job documents. Filters are read from the Upwork search URL passed in `upwork_url`; supported URL parameters (with examples):
+// @Description `q=python` or `search=(python AND automation)` (aliases; conflicting values are rejected with 400), `exclude=wordpress,php` (drops jobs whose text contains any listed term; a value without commas is read as a search expression), `limit=20`, `offset=20`, `payment_verified=1`, `t=hourly` (`job_type` is an alias; sending both with different job types is rejected with 400), `contractor_tier=2`, `contract_to_hire=true`, `has_category=true` (only jobs with category data), `require_title=true` (drops jobs with a blank title; the default comes from REQUIRE_TITLE, off unless set), `has_hourly=true` / `has_fixed=true` (only jobs with a positive hourly rate / fixed budget, whatever their type),
+// @Description `duration_v3=week,month`, `workload=part_time`, `amount=500-2000`, `hourly_rate=25-75`, `min_pay=50` (fixed budget OR hourly max at least 50), `client_hires=1-9`, `client_spend_tier=10k` (client total spend: none, 1k, 10k or 100k+ meaning at least $1k/$10k/$100k; none keeps clients with no spend), `buyer_active_within=7d`, `client_reviews=10-`, `company_size=1-10,1000-`, `industry=tech-it,health-fitness` (case and punctuation are ignored, so tech-it matches Tech & IT; inside upwork_url write a literal & as %26), `invitations=0-2`, `interviewing=true` (clients with interviews under way, i.e. client_activity.total_invited_to_interview of at least 1; false means none, or pass a range such as 1-5; jobs without the count never match), `location=United States` (or regions: africa, europe, caribbean, eu, gcc, asean, latam), `country_exclude=India,PK` (drops jobs whose buyer or location country matches; codes, names and eu/gcc/asean/latam accepted),
+// @Description `timezone=America/New_York`, `proposals=0-4`, `previous_clients=all`, `subcategory2_uid=531770282580668418`, `sort=publish_time_desc,budget_desc` (an unknown sort value is rejected with 400 listing the accepted ones).
+// @Description When the server sets SEARCH_STOPWORDS (off by default), unquoted stopwords in search terms (the, a, job, ...) are ignored; quote a phrase to require them.
+// @Description HEAD /jobs returns the same ETag and Last-Updated headers without a body, for cheap change polling.
+// @Description If the query deadline expires after some matching jobs were found, those are returned with `partial: true` (and not cached).
+// @Description `matched_in_fetch` counts fetched jobs that passed the filters before offset and limit ("showing 20 of 137"). It is best effort: at most 500 documents are fetched per query, so when `matched_is_lower_bound` is true more jobs may match.
+// @Description Sort values: publish_time_asc, publish_time_desc, last_visited_asc, last_visited_desc, budget_asc, budget_desc, created_on_asc, created_on_desc, quality_asc, quality_desc, freshness_asc, freshness_desc (by the later of publish time and last visit), hot (needs FEATURES=relevance_sort; comma-separate for multi-key sorts).
 // @Tags jobs
 // @Produce json
-// @Param upwork_url query string true "Full Upwork job search URL to translate into filters"
+// @Param upwork_url query string true "Full Upwork job search URL to translate into filters" example(https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40)
+// @Param cache_ttl query string false "Admin only: response cache TTL override (e.g. 30s, 5m)" example(30s)
+// @Param debug query string false "Admin only: set to true to bypass the cache and add skipped counts by reason (transform_error, filtered_out, duplicate)" Enums(true, false) default(false) example(true)
+// @Param max_staleness query string false "Exclude jobs not visited within this window (e.g. 72h, 30d); 0 disables the server default" example(30d)
+// @Param strict_order query string false "Set to false to include documents missing the sort field" Enums(true, false) default(true) example(false)
+// @Param case query string false "Response key casing: snake (default) or camel" Enums(snake, camel) default(snake) example(camel)
+// @Param ids_only query string false "Set to true to return only matching job IDs in `ids` (data is null)" Enums(true, false) default(false) example(true)
+// @Param stem query string false "Set to true so search terms also match words sharing their stem (develop matches developer, developing); exact matching otherwise" Enums(true, false) default(false) example(true)
+// @Param format_currency query string false "Set to true to add display strings such as $1,200 next to budget amounts" Enums(true, false) default(false) example(true)
+// @Param min_results query string false "Opt-in: while fewer jobs match, drop filters in this order and retry, listing them in relaxed_filters: buyer_active_within, client_reviews, company_size, invitations, interviewing, proposals, previous_clients, client_spend_tier, client_hires, industry, timezone, duration_v3, workload, contract_to_hire, contractor_tier, min_pay, hourly_rate, amount, payment_verified. Search, job type, location, category and since_id are never relaxed. Must not exceed limit" example(5)
+// @Param since_id query string false "Return only jobs published after this job, given by its numeric ID (the last one the client saw); 400 if it does not exist" example(1971966031232790280)
+// @Param tz query string false "IANA time zone for emitted timestamps (posted_on, created_on, publish_time, last_visited_at); unknown zones fall back to UTC" default(UTC) example(America/New_York)
+// @Param include_similar query string false "Set to true to add the similar jobs listed on private job pages (flagged from_similar)" Enums(true, false) default(false) example(true)
 // @Success 200 {object} JobsResponse
+// @Header 200 {string} ETag "Fingerprint of the returned jobs; unchanged while results are unchanged"
+// @Header 200 {string} Last-Updated "When the response was generated (RFC 3339)"
+// @Header 200 {string} Last-Modified "Newest last_visited_at among the returned jobs; honors If-Modified-Since with 304"
 // @Failure 400 {object} JobsResponse
 // @Failure 401 {object} JobsResponse
 // @Failure 500 {object} JobsResponse
 // @Security ApiKeyAuth
 // @Router /jobs [get]
+// @Router /jobs [head]
 func (s *Server) handleJobs(c *gin.Context) {
 	// Validate query parameters
 	queryParams, err := ValidateAndBindJobsQuery(c)
@@ -218,21 +667,54 @@ func (s *Server) handleJobs(c *gin.Context) {
 		return
 	}
 
+	// Filters decoded from upwork_url (e.g. search) get the same per-value limit
+	if name, length := s.oversizedParam(queryParams.derivedParams); name != "" {
+		respondErrorCode(c, http.StatusBadRequest, ErrCodeQueryTooLong,
+			fmt.Sprintf("upwork_url parameter '%s' is %d characters; the maximum is %d", name, length, s.maxParamLength))
+		return
+	}
+
+	cacheTTL := jobsCacheTTL
+	if queryParams.CacheTTL != "" && isAdminRequest(c) {
+		override, err := parseCacheTTL(queryParams.CacheTTL)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		cacheTTL = override
+		log.Printf("⏳ Admin cache TTL override: %v", cacheTTL)
+	}
+
+	debug := false
+	if queryParams.Debug != "" && isAdminRequest(c) {
+		debug, err = parseFlexibleBool(queryParams.Debug)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid debug: %v", err))
+			return
+		}
+	}
+
 	// Generate cache key from query parameters
 	cacheKey := generateCacheKey("jobs", c.Request.URL.Query())
 
-	// Try to get from cache
+	// Try to get from cache; debug requests always run the query
 	var cachedResponse JobsResponse
-	if err := s.redisClient.Get(c.Request.Context(), cacheKey, &cachedResponse); err == nil {
+	if s.jobsCacheDisabled {
+		// JOBS_CACHE_ENABLED=false: Redis is neither read nor written
+	} else if debug {
+		log.Printf("🐞 Debug /jobs request bypasses the cache")
+	} else if err := s.redisClient.Get(c.Request.Context(), cacheKey, &cachedResponse); err == nil {
 		s.redisClient.Incr(c.Request.Context(), "cache:stats:hits")
 		log.Printf("💚 Cache HIT for /jobs (key: %s)", cacheKey[len(cacheKey)-16:])
-		c.JSON(http.StatusOK, cachedResponse)
+		respondJobs(c, cachedResponse)
 		return
 	}
 
 	// Cache miss - query Firestore
-	s.redisClient.Incr(c.Request.Context(), "cache:stats:misses")
-	log.Printf("💔 Cache MISS for /jobs (key: %s)", cacheKey[len(cacheKey)-16:])
+	if !s.jobsCacheDisabled {
+		s.redisClient.Incr(c.Request.Context(), "cache:stats:misses")
+		log.Printf("💔 Cache MISS for /jobs (key: %s)", cacheKey[len(cacheKey)-16:])
+	}
 
 	// Convert validated params to FilterOptions
 	opts, err := convertToFilterOptions(queryParams)
@@ -241,44 +723,141 @@ func (s *Server) handleJobs(c *gin.Context) {
 		return
 	}
 
+	if opts.hasSortField(SortHot) && !s.features.Enabled(FeatureRelevanceSort) {
+		respondError(c, http.StatusBadRequest, "sort=hot is not enabled on this server")
+		return
+	}
+
+	if queryParams.SinceID != "" {
+		if err := s.resolveSinceID(c.Request.Context(), &opts, queryParams.SinceID); err != nil {
+			if errors.Is(err, errJobNotFound) {
+				respondError(c, http.StatusBadRequest, fmt.Sprintf("since_id job '%s' not found", queryParams.SinceID))
+			} else {
+				respondError(c, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+	}
+
 	log.Printf("🎯 Firestore filter options: %s", formatFilterOptions(opts))
 
-	jobs, err := s.queryJobs(c.Request.Context(), opts)
+	if debug {
+		ctx, stats := withSkipStats(c.Request.Context())
+		response, err := s.jobsResponse(ctx, opts)
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Skipped = stats.summary()
+		respondJobs(c, response)
+		return
+	}
+
+	// Identical concurrent misses share one Firestore query; only the
+	// request that ran it writes the cache
+	response, shared, err := s.coalescer.do(c.Request.Context(), cacheKey, func(ctx context.Context) (JobsResponse, error) {
+		return s.jobsResponse(ctx, opts)
+	})
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if shared {
+		log.Printf("🤝 Coalesced /jobs request with an in-flight query (key: %s)", cacheKey[len(cacheKey)-16:])
+		respondJobs(c, response)
+		return
+	}
 
-	dtos := make([]JobDTO, 0, len(jobs))
-	for _, job := range jobs {
-		dtos = append(dtos, job.ToDTO())
+	// Cache the response (a zero TTL means Redis would never expire it, so skip)
+	if s.jobsCacheDisabled {
+		log.Printf("⏭️ Skipping cache write (JOBS_CACHE_ENABLED=false)")
+	} else if response.Partial {
+		log.Printf("⏭️ Skipping cache write for partial results")
+	} else if cacheTTL <= 0 {
+		log.Printf("⏭️ Skipping cache write (cache_ttl=0)")
+	} else if err := s.redisClient.Set(c.Request.Context(), cacheKey, response, cacheTTL); err != nil {
+		log.Printf("⚠️ Failed to cache response: %v", err)
+	} else {
+		s.indexJobsCacheKey(c.Request.Context(), cacheKey, queryParams.derivedParams, cacheTTL)
+		log.Printf("💾 Cached response for %v", cacheTTL)
 	}
 
-	response := JobsResponse{
-		Success:     true,
-		Data:        dtos,
-		Count:       len(dtos),
-		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	respondJobs(c, response)
+}
+
+// jobsResponse queries jobs for opts and renders the /jobs response body.
+func (s *Server) jobsResponse(ctx context.Context, opts FilterOptions) (JobsResponse, error) {
+	ctx, fetch := withFetchStats(ctx)
+	jobs, partial, relaxed, err := s.queryJobsRelaxed(ctx, opts)
+	if err != nil {
+		return JobsResponse{}, err
+	}
+	matched := int(fetch.matched.Load())
+	// A capped or deadline-cut scan may have left matching documents unread
+	lowerBound := fetch.capped.Load() || partial
+
+	// Sync checks only need IDs, so skip building DTOs
+	if opts.IDsOnly {
+		ids := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+		return JobsResponse{
+			Success:           true,
+			IDs:               ids,
+			Count:             len(ids),
+			MatchedInFetch:    &matched,
+			MatchedLowerBound: lowerBound,
+			Partial:           partial,
+			LastUpdated:       time.Now().UTC().Format(time.RFC3339),
+			LastModified:      newestVisit(jobs),
+			RelaxedFilters:    relaxed,
+		}, nil
 	}
 
-	// Cache the response
-	if err := s.redisClient.Set(c.Request.Context(), cacheKey, response, jobsCacheTTL); err != nil {
-		log.Printf("⚠️ Failed to cache response: %v", err)
-	} else {
-		log.Printf("💾 Cached response for %v", jobsCacheTTL)
+	dtos := make([]JobDTO, 0, len(jobs))
+	for _, job := range jobs {
+		dtos = append(dtos, job.ToDTOWith(DTOOptions{FormatCurrency: opts.FormatCurrency, Location: opts.OutputLocation}))
 	}
 
-	c.JSON(http.StatusOK, response)
+	return JobsResponse{
+		Success:           true,
+		Data:              dtos,
+		Count:             len(dtos),
+		MatchedInFetch:    &matched,
+		MatchedLowerBound: lowerBound,
+		Partial:           partial,
+		LastUpdated:       time.Now().UTC().Format(time.RFC3339),
+		LastModified:      newestVisit(jobs),
+		RelaxedFilters:    relaxed,
+	}, nil
+}
+
+// newestVisit returns the latest LastVisitedAt in jobs as RFC 3339, or ""
+// when none of them carry a visit time.
+func newestVisit(jobs []JobRecord) string {
+	var newest *time.Time
+	for i := range jobs {
+		if visited := jobs[i].LastVisitedAt; visited != nil && (newest == nil || visited.After(*newest)) {
+			newest = visited
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.UTC().Format(time.RFC3339)
 }
 
-func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]JobRecord, error) {
-	ctx := s.rootCtx
+// queryJobs fetches jobs for opts, preferring the read replica. partial is
+// true when the deadline cut the scan short but some jobs were found.
+func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]JobRecord, bool, error) {
+	ctx := inheritFetchStats(inheritSkipStats(inheritReadCounter(s.rootCtx, requestCtx), requestCtx), requestCtx)
 	if requestCtx != nil {
 		if deadline, ok := requestCtx.Deadline(); ok {
 			remaining := time.Until(deadline)
 			if remaining > 0 && remaining < requestTimeout {
 				var cancel context.CancelFunc
-				ctx, cancel = context.WithTimeout(s.rootCtx, remaining)
+				ctx, cancel = context.WithTimeout(ctx, remaining)
 				defer cancel()
 			}
 		}
@@ -287,8 +866,114 @@ func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]Jo
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
+	if opts.MaxStaleness == nil && s.maxStaleness > 0 {
+		staleness := s.maxStaleness
+		opts.MaxStaleness = &staleness
+	}
+
+	if s.replicaClient != nil {
+		replicaCtx, cancelReplica := context.WithTimeout(ctx, replicaQueryTimeout)
+		results, partial, err := s.fetchJobs(replicaCtx, s.replicaClient, opts)
+		cancelReplica()
+		// A replica cut short by its own budget is retried on the primary
+		if err == nil && (!partial || ctx.Err() != nil) {
+			return results, partial, nil
+		}
+		if err != nil && ctx.Err() != nil {
+			return nil, false, err
+		}
+		if err == nil {
+			err = errors.New("replica returned partial results")
+		}
+		log.Printf("⚠️ Replica query failed, falling back to primary: %v", err)
+	}
+
+	return s.fetchJobs(ctx, s.client, opts)
+}
+
+// collectJobs transforms every document returned by query, applies filters
+// and appends new matches to results. Documents for which skip returns true
+// are ignored; seen tracks job IDs already present in results. If the
+// deadline expires after some matches were gathered, it stops early and
+// reports partial=true instead of an error.
+func (s *Server) collectJobs(ctx context.Context, query firestore.Query, opts FilterOptions, results []JobRecord, seen map[string]struct{}, skip func(*firestore.DocumentSnapshot) bool) ([]JobRecord, int, bool, error) {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	docCount := 0
+	refetched := 0
+	partial := false
+	var batch, similar []JobRecord
+	defer func() { addDocReads(ctx, docCount) }()
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if isDeadlineExceeded(err) && len(batch)+len(similar) > 0 {
+			log.Printf("⏱️ Deadline exceeded after %d docs, returning partial results", docCount)
+			partial = true
+			break
+		}
+		if err != nil {
+			if isContextCanceled(err) {
+				return nil, docCount, false, fmt.Errorf("firestore query cancelled: %w", err)
+			}
+			return nil, docCount, false, fmt.Errorf("firestore query failed: %w", err)
+		}
+		docCount++
+
+		if skip != nil && skip(doc) {
+			continue
+		}
+
+		records, err := transformDocument(doc)
+		if err != nil && s.projectFields && ctx.Err() == nil {
+			// The projection may have missed fields this document relies on
+			if full, getErr := doc.Ref.Get(ctx); getErr == nil {
+				refetched++
+				records, err = transformDocument(full)
+			}
+		}
+		if err != nil {
+			log.Printf("Skipping document %s: %v", doc.Ref.ID, err)
+			if stats := skipStatsFrom(ctx); stats != nil {
+				stats.transformError.Add(1)
+			}
+			continue
+		}
+
+		batch = append(batch, records...)
+
+		if opts.IncludeSimilar && hasPrivateRecord(records) {
+			similar = append(similar, similarJobRecords(doc.Data(), doc.Ref.ID)...)
+		}
+	}
+
+	before := len(results)
+	stats := skipStatsFrom(ctx)
+	results = filterBatch(batch, opts, seen, results, stats)
+	// Similar jobs go last so a job's own document always wins the dedup
+	results = filterBatch(similar, opts, seen, results, stats)
+
+	if partial && len(results) == before {
+		return nil, docCount, false, fmt.Errorf("firestore query timed out before any matching jobs were found: %w", context.DeadlineExceeded)
+	}
+
+	if refetched > 0 {
+		log.Printf("🔁 Re-fetched %d docs in full after projected transform failed", refetched)
+	}
+
+	return results, docCount, partial, nil
+}
+
+// fetchJobs runs the ordered Firestore query against client and applies
+// in-memory filters, sorting and pagination. partial reports that the
+// deadline cut the scan short.
+func (s *Server) fetchJobs(ctx context.Context, client *firestore.Client, opts FilterOptions) ([]JobRecord, bool, error) {
 	// Build Firestore query with native ordering
-	query := s.client.Collection(s.collectionName).Query
+	query := client.Collection(s.collectionName).Query
 
 	// Use Firestore native ordering with flattened fields
 	var orderField string
@@ -311,6 +996,36 @@ func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]Jo
 		} else {
 			orderDir = firestore.Desc
 		}
+	case SortCreatedOn:
+		// Flattened createdOn; re-sorted in memory since formats vary and
+		// jobs without a creation time go last
+		orderField = "createdOn"
+		if opts.SortAscending {
+			orderDir = firestore.Asc
+		} else {
+			orderDir = firestore.Desc
+		}
+		needsInMemorySort = true
+	case SortHot:
+		// Newest jobs form the candidate window; hot scores are applied in memory
+		orderField = "publishTime"
+		orderDir = firestore.Desc
+		needsInMemorySort = true
+	case SortFreshness:
+		// Visits follow publication, so recently visited jobs form the
+		// candidate window; the blended time is applied in memory
+		orderField = "scrape_metadata.last_visited_at"
+		if opts.SortAscending {
+			orderDir = firestore.Asc
+		} else {
+			orderDir = firestore.Desc
+		}
+		needsInMemorySort = true
+	case SortQuality:
+		// Quality is derived from buyer stats, so rank the newest jobs in memory
+		orderField = "publishTime"
+		orderDir = firestore.Desc
+		needsInMemorySort = true
 	case SortBudget:
 		// Use flattened budget fields, but still need in-memory sort to handle both fixed and hourly
 		orderField = "budgetAmount"
@@ -322,6 +1037,16 @@ func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]Jo
 		orderDir = firestore.Desc
 	}
 
+	// Secondary keys are applied in memory on top of the primary ordering
+	if len(opts.sortKeys()) > 1 {
+		needsInMemorySort = true
+	}
+	// Firestore still orders by the stored publishTime, so jobs whose future
+	// publish time was dropped must be moved to the end in memory
+	if orderField == "publishTime" && futurePublishAction == FuturePublishNull {
+		needsInMemorySort = true
+	}
+
 	query = query.OrderBy(orderField, orderDir)
 
 	// Calculate fetch limit
@@ -337,56 +1062,67 @@ func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]Jo
 	}
 
 	query = query.Limit(fetchLimit)
-
-	iter := query.Documents(ctx)
-	defer iter.Stop()
+	if s.projectFields {
+		query = query.Select(jobProjectionPaths...)
+	}
 
 	results := make([]JobRecord, 0, opts.Limit)
-	docCount := 0
-
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			if isContextCanceled(err) {
-				return nil, fmt.Errorf("firestore query cancelled: %w", err)
-			}
-			return nil, fmt.Errorf("firestore query failed: %w", err)
+	seen := make(map[string]struct{})
+	results, docCount, partial, err := s.collectJobs(ctx, query, opts, results, seen, nil)
+	unordered := false
+	if link, ok := missingIndexLink(err); ok {
+		// Serve an unordered window sorted in memory until the index exists
+		log.Printf("🗂️ Ordering by %s needs a Firestore index, falling back to an unordered scan; create it at: %s", orderField, link)
+		query = client.Collection(s.collectionName).Limit(fetchLimit)
+		if s.projectFields {
+			query = query.Select(jobProjectionPaths...)
 		}
-		docCount++
+		results, docCount, partial, err = s.collectJobs(ctx, query, opts, results, seen, nil)
+		unordered = true
+		needsInMemorySort = true
+	}
+	if err != nil {
+		return nil, false, err
+	}
 
-		records, err := transformDocument(doc)
+	log.Printf("📊 Fetched %d docs from Firestore (ordered by %s %v), filtered to %d results", docCount, orderField, orderDir, len(results))
+	// Hitting the fetch limit means later documents were never examined
+	capped := docCount >= fetchLimit
+
+	// OrderBy silently drops documents without the order field. When the page
+	// comes up short, scan unordered for such documents and sort in memory.
+	if !partial && !unordered && !opts.StrictOrder && len(results) < opts.Offset+opts.Limit {
+		fallback := client.Collection(s.collectionName).Limit(fetchLimit)
+		if s.projectFields {
+			fallback = fallback.Select(append(append([]string{}, jobProjectionPaths...), orderField)...)
+		}
+		before := len(results)
+		results, docCount, partial, err = s.collectJobs(ctx, fallback, opts, results, seen, func(doc *firestore.DocumentSnapshot) bool {
+			_, err := doc.DataAt(orderField)
+			return err == nil // already covered by the ordered query
+		})
 		if err != nil {
-			log.Printf("Skipping document %s: %v", doc.Ref.ID, err)
-			continue
+			return nil, false, err
 		}
-
-		for _, rec := range records {
-			job := rec
-			if !applyFilters(&job, opts) {
-				continue
-			}
-
-			if opts.SearchExpression != nil && !matchesSearchExpression(&job, opts.SearchExpression) {
-				continue
-			}
-
-			results = append(results, job)
+		capped = capped || docCount >= fetchLimit
+		if added := len(results) - before; added > 0 {
+			log.Printf("🧩 Merged %d results missing %s (scanned %d docs unordered)", added, orderField, docCount)
+			needsInMemorySort = true
 		}
 	}
 
-	log.Printf("📊 Fetched %d docs from Firestore (ordered by %s %v), filtered to %d results", docCount, orderField, orderDir, len(results))
-
-	// In-memory sorting only if needed (budget sorting)
-	if needsInMemorySort {
-		sortJobs(results, opts)
+	if stats := fetchStatsFrom(ctx); stats != nil {
+		stats.record(len(results), capped)
 	}
 
+	// Always sort in memory: Firestore may return tied documents in a
+	// different order between reads, and the ID tiebreak keeps cached and
+	// fresh pages identical. needsInMemorySort only widens the fetch window.
+	sortJobs(results, opts)
+
 	if opts.Offset > 0 {
 		if opts.Offset >= len(results) {
-			return []JobRecord{}, nil
+			return []JobRecord{}, partial, nil
 		}
 		results = results[opts.Offset:]
 	}
@@ -394,16 +1130,17 @@ func (s *Server) queryJobs(requestCtx context.Context, opts FilterOptions) ([]Jo
 		results = results[:opts.Limit]
 	}
 
-	return results, nil
+	return results, partial, nil
 }
 
 // handleRefreshAPIKeysCache forces a refresh of the API keys cache
 // @Summary Refresh API keys cache
-// @Description Forces a refresh of the API keys cache from Firestore
+// @Description Admin only. Forces a refresh of the API keys cache from Firestore
 // @Tags api-keys
 // @Produce json
 // @Success 200 {object} JobsResponse
 // @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
 // @Failure 500 {object} JobsResponse
 // @Security ApiKeyAuth
 // @Router /api-keys/refresh-cache [post]
@@ -422,12 +1159,13 @@ func (s *Server) handleRefreshAPIKeysCache(c *gin.Context) {
 
 // handleClearAPIKeyCache clears a specific API key from cache
 // @Summary Clear API key cache
-// @Description Removes a specific API key from the cache
+// @Description Admin only. Removes a specific API key from the cache
 // @Tags api-keys
 // @Produce json
 // @Param key path string true "API key to clear from cache"
 // @Success 200 {object} JobsResponse
 // @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
 // @Failure 500 {object} JobsResponse
 // @Security ApiKeyAuth
 // @Router /api-keys/{key}/cache [delete]
@@ -452,11 +1190,12 @@ func (s *Server) handleClearAPIKeyCache(c *gin.Context) {
 
 // handleCacheStats returns cache hit/miss statistics
 // @Summary Get cache statistics
-// @Description Returns cache hit/miss ratio and performance metrics
+// @Description Admin only. Returns cache hit/miss ratio and performance metrics. Coalescing counters (queries_executed, requests_coalesced, firestore_reads_saved) are per instance since startup.
 // @Tags cache
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
 // @Failure 500 {object} JobsResponse
 // @Security ApiKeyAuth
 // @Router /cache/stats [get]
@@ -466,6 +1205,9 @@ func (s *Server) handleCacheStats(c *gin.Context) {
 		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Failed to get cache stats: %v", err))
 		return
 	}
+	for name, value := range s.coalescer.stats() {
+		stats[name] = value
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -475,27 +1217,19 @@ func (s *Server) handleCacheStats(c *gin.Context) {
 
 // handleClearCache clears all response caches
 // @Summary Clear all response caches
-// @Description Removes all cached responses (does not affect API key cache)
+// @Description Admin only. Removes all cached responses (does not affect API key cache)
 // @Tags cache
 // @Produce json
 // @Success 200 {object} JobsResponse
 // @Failure 401 {object} JobsResponse
+// @Failure 403 {object} JobsResponse
 // @Failure 500 {object} JobsResponse
 // @Security ApiKeyAuth
 // @Router /cache/clear [delete]
 func (s *Server) handleClearCache(c *gin.Context) {
 	// Clear response caches (keys starting with "response:")
-	ctx := c.Request.Context()
-	iter := s.redisClient.client.Scan(ctx, 0, "response:*", 0).Iterator()
-	count := 0
-	for iter.Next(ctx) {
-		if err := s.redisClient.Delete(ctx, iter.Val()); err != nil {
-			log.Printf("Failed to delete cache key %s: %v", iter.Val(), err)
-		} else {
-			count++
-		}
-	}
-	if err := iter.Err(); err != nil {
+	count, err := s.redisClient.deleteMatching(c.Request.Context(), s.redisClient.key("response:*"))
+	if err != nil {
 		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Failed to clear cache: %v", err))
 		return
 	}
@@ -508,14 +1242,109 @@ func (s *Server) handleClearCache(c *gin.Context) {
 	})
 }
 
+// respondJobs writes a successful /jobs response with ETag, Last-Updated and
+// Last-Modified headers, answering 304 when If-Modified-Since is not older
+// than the data. HEAD requests get the headers without a body; case=camel
+// re-keys the body to camelCase.
+func respondJobs(c *gin.Context, response JobsResponse) {
+	camel := strings.EqualFold(c.Query("case"), caseCamel)
+	if etag := jobsETag(response); etag != "" {
+		if camel {
+			etag = strings.TrimSuffix(etag, `"`) + `-camel"`
+		}
+		c.Header("ETag", etag)
+	}
+	c.Header("Last-Updated", response.LastUpdated)
+
+	if modified, err := time.Parse(time.RFC3339, response.LastModified); err == nil {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+		if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !modified.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	if c.Request.Method == http.MethodHead {
+		c.Header("Content-Type", "application/json; charset=utf-8")
+		c.Status(http.StatusOK)
+		return
+	}
+	if camel {
+		body, err := camelizeJSON(response)
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, fmt.Sprintf("Failed to re-key response: %v", err))
+			return
+		}
+		c.JSON(http.StatusOK, body)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// jobsETag fingerprints the jobs in a response. LastUpdated is left out so
+// unchanged results keep their tag across requests.
+func jobsETag(response JobsResponse) string {
+	payload, err := json.Marshal(struct {
+		Data    []JobDTO
+		IDs     []string
+		Partial bool
+	}{response.Data, response.IDs, response.Partial})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(payload)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
 func respondError(c *gin.Context, status int, message string) {
+	respondErrorCode(c, status, "", message)
+}
+
+// Machine-readable error codes returned in JobsResponse.ErrorCode
+const (
+	ErrCodeQueryTooLong = "QUERY_TOO_LONG"
+	ErrCodeBodyTooLarge = "BODY_TOO_LARGE"
+	ErrCodeMaintenance  = "MAINTENANCE"
+)
+
+func respondErrorCode(c *gin.Context, status int, code string, message string) {
 	c.JSON(status, JobsResponse{
 		Success:     false,
 		Message:     message,
+		ErrorCode:   code,
 		LastUpdated: time.Now().UTC().Format(time.RFC3339),
 	})
 }
 
+func isDeadlineExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return status.Code(err) == codes.DeadlineExceeded
+}
+
+// missingIndexLink reports whether err is Firestore's FAILED_PRECONDITION
+// for a query that needs a composite index, returning the console link to
+// create it when the message carries one.
+func missingIndexLink(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition || !strings.Contains(st.Message(), "requires an index") {
+		return "", false
+	}
+	if link := indexLinkPattern.FindString(st.Message()); link != "" {
+		return link, true
+	}
+	return "(no link in error)", true
+}
+
+var indexLinkPattern = regexp.MustCompile(`https://\S+`)
+
 func isContextCanceled(err error) bool {
 	if err == nil {
 		return false
@@ -526,7 +1355,30 @@ func isContextCanceled(err error) bool {
 	return status.Code(err) == codes.Canceled
 }
 
-// generateCacheKey creates a deterministic cache key from query parameters
+// parseCacheTTL parses a cache_ttl override given as a Go duration ("30s")
+// or a number of seconds. Zero disables caching for the response.
+func parseCacheTTL(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid cache_ttl '%s': use a duration like 30s or 5m", raw)
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	if ttl < 0 || ttl > maxCacheTTLOverride {
+		return 0, fmt.Errorf("cache_ttl must be between 0s and %v", maxCacheTTLOverride)
+	}
+	return ttl, nil
+}
+
+// generateCacheKey creates a deterministic cache key from query parameters.
+// Keys are shared across API keys, which is safe only while a /jobs response
+// depends on nothing but the query: scopes currently gate admin-only
+// parameters (already part of the query), not the returned jobs. Per-key
+// forced filters must add the key's restriction fingerprint here so
+// restricted and unrestricted responses never share an entry.
 func generateCacheKey(endpoint string, queryParams map[string][]string) string {
 	// Sort keys for deterministic output
 	keys := make([]string, 0, len(queryParams))
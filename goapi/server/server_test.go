@@ -0,0 +1,764 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	_ "upwork-job-api/docs"
+)
+
+// newEmulatorServer returns a Server bound to a fresh collection on the
+// Firestore emulator. Tests are skipped when FIRESTORE_EMULATOR_HOST is unset.
+func newEmulatorServer(t *testing.T) *Server {
+	t.Helper()
+
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST not set; skipping Firestore emulator test")
+	}
+
+	projectID := os.Getenv("FIREBASE_PROJECT_ID")
+	if projectID == "" {
+		projectID = defaultEmulatorProjectID
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, err := firestore.NewClient(ctx, projectID)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to create emulator client: %v", err)
+	}
+
+	srv := &Server{
+		rootCtx:        ctx,
+		cancelRoot:     cancel,
+		client:         client,
+		collectionName: fmt.Sprintf("jobs_test_%d", time.Now().UnixNano()),
+		projectFields:  true,
+	}
+
+	t.Cleanup(func() {
+		refs, err := client.Collection(srv.collectionName).DocumentRefs(context.Background()).GetAll()
+		if err == nil {
+			for _, ref := range refs {
+				ref.Delete(context.Background())
+			}
+		}
+		client.Close()
+		cancel()
+	})
+
+	return srv
+}
+
+type seedJob struct {
+	id          string
+	title       string
+	publishTime time.Time
+	budget      float64
+	hourlyMax   float64
+	jobType     int
+	verified    bool
+	country     string
+}
+
+func (j seedJob) document() map[string]interface{} {
+	job := map[string]interface{}{
+		"uid":         j.id,
+		"title":       j.title,
+		"description": j.title + " description",
+		"type":        j.jobType,
+		"publishTime": j.publishTime.Format(time.RFC3339),
+	}
+	if j.budget > 0 {
+		job["budget"] = map[string]interface{}{"amount": j.budget, "currencyCode": "USD"}
+	}
+	if j.hourlyMax > 0 {
+		job["hourlyBudgetMax"] = j.hourlyMax
+	}
+
+	// Root-level fields mirror what cmd/migrate-flatten-fields writes.
+	doc := map[string]interface{}{
+		"url":         "https://www.upwork.com/jobs/" + j.id,
+		"publishTime": job["publishTime"],
+		"state": map[string]interface{}{
+			"jobDetails": map[string]interface{}{
+				"job": job,
+				"buyer": map[string]interface{}{
+					"isPaymentMethodVerified": j.verified,
+					"location":                map[string]interface{}{"country": j.country},
+				},
+			},
+		},
+		"scrape_metadata": map[string]interface{}{
+			"last_visited_at": j.publishTime.Add(time.Hour).Format(time.RFC3339),
+		},
+	}
+	if j.budget > 0 {
+		doc["budgetAmount"] = j.budget
+	}
+	if j.hourlyMax > 0 {
+		doc["hourlyBudgetMax"] = j.hourlyMax
+	}
+	return doc
+}
+
+func seedJobs(t *testing.T, srv *Server, jobs []seedJob) {
+	t.Helper()
+	ctx := context.Background()
+	for _, job := range jobs {
+		if _, err := srv.client.Collection(srv.collectionName).Doc(job.id).Set(ctx, job.document()); err != nil {
+			t.Fatalf("failed to seed %s: %v", job.id, err)
+		}
+	}
+}
+
+func jobIDs(jobs []JobRecord) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestQueryJobsAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2, verified: true, country: "US"},
+		{id: "job-b", title: "React dashboard", publishTime: base.Add(-2 * time.Hour), hourlyMax: 60, jobType: 1, verified: false, country: "DE"},
+		{id: "job-c", title: "Python API", publishTime: base.Add(-3 * time.Hour), budget: 1500, jobType: 2, verified: true, country: "GB"},
+		{id: "job-d", title: "Go microservice", publishTime: base.Add(-4 * time.Hour), hourlyMax: 90, jobType: 1, verified: true, country: "US"},
+	})
+
+	verified := true
+
+	tests := []struct {
+		name string
+		opts func() FilterOptions
+		want []string
+	}{
+		{
+			name: "default publish time descending",
+			opts: func() FilterOptions { return FilterOptions{Limit: 10, SortField: SortPublishTime} },
+			want: []string{"job-a", "job-b", "job-c", "job-d"},
+		},
+		{
+			name: "publish time ascending",
+			opts: func() FilterOptions {
+				return FilterOptions{Limit: 10, SortField: SortPublishTime, SortAscending: true}
+			},
+			want: []string{"job-d", "job-c", "job-b", "job-a"},
+		},
+		{
+			name: "payment verified and job type",
+			opts: func() FilterOptions {
+				return FilterOptions{Limit: 10, SortField: SortPublishTime, PaymentVerified: &verified, JobTypeCodes: []int{2}}
+			},
+			want: []string{"job-a", "job-c"},
+		},
+		{
+			// Firestore drops documents without budgetAmount from the ordered query.
+			name: "budget descending with strict order",
+			opts: func() FilterOptions { return FilterOptions{Limit: 10, SortField: SortBudget, StrictOrder: true} },
+			want: []string{"job-c", "job-a"},
+		},
+		{
+			// Hourly jobs lack budgetAmount and are merged in by the unordered fallback.
+			name: "budget descending merges documents missing the order field",
+			opts: func() FilterOptions { return FilterOptions{Limit: 10, SortField: SortBudget} },
+			want: []string{"job-c", "job-a", "job-d", "job-b"},
+		},
+		{
+			name: "search expression with offset",
+			opts: func() FilterOptions {
+				opts := FilterOptions{Limit: 1, Offset: 1, SortField: SortPublishTime}
+				if err := opts.ApplySearchQuery("python"); err != nil {
+					t.Fatalf("unexpected search error: %v", err)
+				}
+				return opts
+			},
+			want: []string{"job-c"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobs, _, err := srv.queryJobs(context.Background(), tc.opts())
+			if err != nil {
+				t.Fatalf("queryJobs failed: %v", err)
+			}
+			if got := jobIDs(jobs); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected job order: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJobsResponseIDsOnlyAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2},
+		{id: "job-b", title: "React dashboard", publishTime: base.Add(-2 * time.Hour), hourlyMax: 60, jobType: 1},
+	})
+
+	response, err := srv.jobsResponse(context.Background(), FilterOptions{Limit: 10, SortField: SortPublishTime, IDsOnly: true})
+	if err != nil {
+		t.Fatalf("jobsResponse failed: %v", err)
+	}
+	if response.Data != nil || response.Count != 2 || !reflect.DeepEqual(response.IDs, []string{"job-a", "job-b"}) {
+		t.Fatalf("unexpected ids_only response: %+v", response)
+	}
+}
+
+func TestJobsResponseMatchedInFetch(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2},
+		{id: "job-b", title: "Go bot", publishTime: base.Add(-2 * time.Hour), budget: 500, jobType: 2},
+		{id: "job-c", title: "React dashboard", publishTime: base.Add(-3 * time.Hour), hourlyMax: 60, jobType: 1},
+	})
+
+	response, err := srv.jobsResponse(context.Background(), FilterOptions{Limit: 1, SortField: SortPublishTime, JobTypeCodes: []int{2}})
+	if err != nil {
+		t.Fatalf("jobsResponse failed: %v", err)
+	}
+	if response.Count != 1 || response.MatchedInFetch == nil || *response.MatchedInFetch != 2 || response.MatchedLowerBound {
+		t.Fatalf("expected 1 of 2 matched jobs, got %+v", response)
+	}
+}
+
+func TestHandleJobsWithCacheDisabled(t *testing.T) {
+	srv := newEmulatorServer(t)
+	// No Redis client: any cache read or write would panic
+	srv.jobsCacheDisabled = true
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2},
+	})
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs?upwork_url="+url.QueryEscape("https://www.upwork.com/nx/search/jobs/?sort=recency"), nil)
+	srv.handleJobs(c)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 without Redis, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSinceIDAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Newest", publishTime: base.Add(-1 * time.Hour), budget: 500, jobType: 2},
+		{id: "job-b", title: "Seen", publishTime: base.Add(-2 * time.Hour), budget: 500, jobType: 2},
+		{id: "job-c", title: "Older", publishTime: base.Add(-3 * time.Hour), budget: 500, jobType: 2},
+	})
+
+	opts := FilterOptions{Limit: 10, SortField: SortPublishTime}
+	if err := srv.resolveSinceID(context.Background(), &opts, "job-b"); err != nil {
+		t.Fatalf("resolveSinceID failed: %v", err)
+	}
+	jobs, _, err := srv.queryJobs(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("queryJobs failed: %v", err)
+	}
+	if got := jobIDs(jobs); !reflect.DeepEqual(got, []string{"job-a"}) {
+		t.Fatalf("expected only jobs newer than job-b, got %v", got)
+	}
+
+	if err := srv.resolveSinceID(context.Background(), &opts, "missing"); !errors.Is(err, errJobNotFound) {
+		t.Fatalf("expected errJobNotFound for an unknown since_id, got %v", err)
+	}
+}
+
+func TestParseCacheTTL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{raw: "30s", want: 30 * time.Second},
+		{raw: "5m", want: 5 * time.Minute},
+		{raw: "45", want: 45 * time.Second},
+		{raw: "0", want: 0},
+		{raw: "-1s", wantErr: true},
+		{raw: "2h", wantErr: true},
+		{raw: "soon", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := parseCacheTTL(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("parseCacheTTL(%q): expected error, got %v", tc.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseCacheTTL(%q): unexpected error: %v", tc.raw, err)
+		}
+		if got != tc.want {
+			t.Fatalf("parseCacheTTL(%q) = %v, want %v", tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestIsAdminRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(c *gin.Context)
+		want bool
+	}{
+		{name: "no key info", set: func(c *gin.Context) {}, want: false},
+		{name: "legacy key", set: func(c *gin.Context) { c.Set("legacy_api_key", true) }, want: true},
+		{name: "key without scopes", set: func(c *gin.Context) { c.Set("api_key_info", &APIKey{}) }, want: false},
+		{name: "admin scoped key", set: func(c *gin.Context) {
+			c.Set("api_key_info", &APIKey{Scopes: []string{"read", "Admin"}})
+		}, want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			tc.set(c)
+			if got := isAdminRequest(c); got != tc.want {
+				t.Fatalf("isAdminRequest() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name string
+		key  *APIKey
+		want int
+	}{
+		{name: "no key info", want: http.StatusForbidden},
+		{name: "key without scope", key: &APIKey{Scopes: []string{"read"}}, want: http.StatusForbidden},
+		{name: "scoped key", key: &APIKey{Scopes: []string{"admin"}}, want: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.key != nil {
+					c.Set("api_key_info", tc.key)
+				}
+			})
+			router.GET("/cache/stats", requireScope(ScopeAdmin), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cache/stats", nil))
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetJobsByIDAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	base := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: base, budget: 500, jobType: 2, verified: true, country: "US"},
+		{id: "job-b", title: "React dashboard", publishTime: base, hourlyMax: 60, jobType: 1, country: "DE"},
+	})
+
+	found, missing, err := srv.getJobsByID(context.Background(), []string{"job-b", "job-x", "job-a"})
+	if err != nil {
+		t.Fatalf("getJobsByID failed: %v", err)
+	}
+	if len(found) != 2 || found["job-a"].Title != "Python scraper" || found["job-b"].Title != "React dashboard" {
+		t.Fatalf("unexpected found jobs: %+v", found)
+	}
+	if !reflect.DeepEqual(missing, []string{"job-x"}) {
+		t.Fatalf("unexpected missing IDs: %v", missing)
+	}
+}
+
+func TestDedupeIDs(t *testing.T) {
+	got := dedupeIDs([]string{" job-a ", "job-b", "", "job-a", "job-c", "job-b"})
+	want := []string{"job-a", "job-b", "job-c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("dedupeIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestOpenAPISpecEndpoint(t *testing.T) {
+	router := (&Server{}).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 without an API key, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var spec struct {
+		Swagger     string                 `json:"swagger"`
+		Paths       map[string]interface{} `json:"paths"`
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+	if spec.Swagger != "2.0" {
+		t.Fatalf("unexpected swagger version %q", spec.Swagger)
+	}
+	if _, ok := spec.Paths["/jobs"]; !ok {
+		t.Fatalf("spec is missing /jobs path")
+	}
+	for _, name := range []string{"server.JobDTO", "server.JobsResponse", "server.JobsBatchResponse"} {
+		if _, ok := spec.Definitions[name]; !ok {
+			t.Fatalf("spec is missing definition %s", name)
+		}
+	}
+}
+
+// TestJobsSwaggerExamplesMatchValidation keeps the /jobs Swagger annotations
+// in sync with jobsParamExamples (used by getFieldExample).
+func TestJobsSwaggerExamplesMatchValidation(t *testing.T) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Description string `json:"description"`
+			Parameters  []struct {
+				Name    string      `json:"name"`
+				Example interface{} `json:"example"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+	op := spec.Paths["/jobs"]["get"]
+
+	documented := map[string]bool{}
+	for _, param := range op.Parameters {
+		documented[param.Name] = true
+		want, ok := jobsParamExamples[param.Name]
+		if !ok {
+			t.Fatalf("parameter %s has no entry in jobsParamExamples", param.Name)
+		}
+		if fmt.Sprint(param.Example) != want {
+			t.Fatalf("parameter %s: swagger example %v, validation example %q", param.Name, param.Example, want)
+		}
+	}
+	for name := range jobsControlParams {
+		if !documented[name] {
+			t.Fatalf("control parameter %s is missing a @Param annotation", name)
+		}
+	}
+
+	for name := range supportedAPIParams {
+		example, ok := jobsParamExamples[name]
+		if !ok {
+			t.Fatalf("upwork_url filter %s has no entry in jobsParamExamples", name)
+		}
+		if !strings.Contains(op.Description, "`"+name+"="+example+"`") {
+			t.Fatalf("upwork_url filter %s=%s is not listed in the /jobs @Description", name, example)
+		}
+	}
+}
+
+func TestQueryLengthGuard(t *testing.T) {
+	router := (&Server{maxQueryLength: 64, maxParamLength: 32}).Router()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "query string too long", query: "a=" + strings.Repeat("x", 20) + "&b=" + strings.Repeat("y", 20) + "&c=" + strings.Repeat("z", 20)},
+		{name: "single param too long", query: "upwork_url=" + strings.Repeat("x", 40)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs?"+tc.query, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var resp JobsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response body: %v", err)
+			}
+			if resp.ErrorCode != ErrCodeQueryTooLong {
+				t.Fatalf("expected error_code %s, got %q", ErrCodeQueryTooLong, resp.ErrorCode)
+			}
+		})
+	}
+
+	// Within the limits the request reaches auth instead
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs?upwork_url=short", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a short query without a key, got %d", rec.Code)
+	}
+}
+
+func TestBodySizeLimit(t *testing.T) {
+	srv := &Server{maxBodyBytes: 64}
+	body := `{"ids": ["` + strings.Repeat("x", 100) + `"]}`
+
+	// A declared Content-Length over the cap is rejected before auth
+	rec := httptest.NewRecorder()
+	srv.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jobs/batch", strings.NewReader(body)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Without a Content-Length the cap applies while the handler reads
+	rec = httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/jobs/batch", strings.NewReader(body))
+	c.Request.ContentLength = -1
+	srv.bodySizeMiddleware()(c)
+	srv.handleJobsBatch(c)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 while reading, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp JobsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.ErrorCode != ErrCodeBodyTooLarge {
+		t.Fatalf("expected error_code %s, got %s", ErrCodeBodyTooLarge, rec.Body.String())
+	}
+}
+
+func TestEffectiveConfigOmitsSecrets(t *testing.T) {
+	srv := &Server{
+		apiKey:         "legacy-secret-key",
+		collectionName: "jobs_test",
+		projectFields:  true,
+		maxQueryLength: defaultMaxQueryLength,
+		maxStaleness:   72 * time.Hour,
+	}
+
+	cfg := srv.effectiveConfig()
+	if cfg.Collections.Jobs != "jobs_test" || !cfg.Features.FirestoreProjection || cfg.Features.ReadReplica {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.Limits.MaxStaleness != "72h0m0s" {
+		t.Fatalf("unexpected max staleness %q", cfg.Limits.MaxStaleness)
+	}
+
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	if strings.Contains(string(body), srv.apiKey) {
+		t.Fatalf("config leaks the legacy API key: %s", body)
+	}
+}
+
+func TestRespondJobsHeaders(t *testing.T) {
+	response := JobsResponse{Success: true, Data: []JobDTO{{ID: "job-a"}}, Count: 1, LastUpdated: "2025-01-10T12:00:00Z"}
+
+	serve := func(method string, resp JobsResponse) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(method, "/jobs", nil)
+		respondJobs(c, resp)
+		c.Writer.WriteHeaderNow()
+		return rec
+	}
+
+	get := serve(http.MethodGet, response)
+	head := serve(http.MethodHead, response)
+	if get.Header().Get("ETag") == "" || get.Header().Get("ETag") != head.Header().Get("ETag") {
+		t.Fatalf("expected matching ETags, got %q and %q", get.Header().Get("ETag"), head.Header().Get("ETag"))
+	}
+	if head.Header().Get("Last-Updated") != response.LastUpdated || head.Code != http.StatusOK {
+		t.Fatalf("unexpected HEAD response: %d %v", head.Code, head.Header())
+	}
+	if head.Body.Len() != 0 || get.Body.Len() == 0 {
+		t.Fatalf("expected a body for GET only, got GET=%d HEAD=%d bytes", get.Body.Len(), head.Body.Len())
+	}
+
+	// A later refresh with the same jobs keeps the tag
+	response.LastUpdated = "2025-01-10T12:05:00Z"
+	if etag := serve(http.MethodGet, response).Header().Get("ETag"); etag != get.Header().Get("ETag") {
+		t.Fatalf("expected ETag to ignore last_updated")
+	}
+	response.Data[0].ID = "job-b"
+	if etag := serve(http.MethodGet, response).Header().Get("ETag"); etag == get.Header().Get("ETag") {
+		t.Fatalf("expected ETag to change with the jobs")
+	}
+
+	// Auth still applies to HEAD
+	rec := httptest.NewRecorder()
+	(&Server{maxQueryLength: 1024, maxParamLength: 512}).Router().ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/jobs?upwork_url=short", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for HEAD without a key, got %d", rec.Code)
+	}
+}
+
+func TestRespondJobsLastModified(t *testing.T) {
+	serve := func(resp JobsResponse, since string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodGet, "/jobs", nil)
+		if since != "" {
+			c.Request.Header.Set("If-Modified-Since", since)
+		}
+		respondJobs(c, resp)
+		c.Writer.WriteHeaderNow()
+		return rec
+	}
+
+	visited := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	response := JobsResponse{
+		Success:      true,
+		Data:         []JobDTO{{ID: "job-a"}},
+		Count:        1,
+		LastModified: newestVisit([]JobRecord{{LastVisitedAt: &visited}, {}}),
+	}
+
+	rec := serve(response, "")
+	if got := rec.Header().Get("Last-Modified"); got != "Fri, 10 Jan 2025 12:00:00 GMT" || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response: %d Last-Modified=%q", rec.Code, got)
+	}
+	if rec := serve(response, "Fri, 10 Jan 2025 12:00:00 GMT"); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("expected empty 304, got %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+	if rec := serve(response, "Fri, 10 Jan 2025 11:59:59 GMT"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for older If-Modified-Since, got %d", rec.Code)
+	}
+
+	// No visit times: no header, and If-Modified-Since is ignored
+	response.LastModified = newestVisit([]JobRecord{{}})
+	if rec := serve(response, "Fri, 10 Jan 2025 12:00:00 GMT"); rec.Code != http.StatusOK || rec.Header().Get("Last-Modified") != "" {
+		t.Fatalf("expected plain 200 without timestamps, got %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestJobByIDIncludeRawRequiresAdmin(t *testing.T) {
+	srv := &Server{}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs/job-a?include_raw=true", nil)
+	c.Params = gin.Params{{Key: "id", Value: "job-a"}}
+
+	srv.handleJobByID(c)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for include_raw without admin scope, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetJobByIDAgainstEmulator(t *testing.T) {
+	srv := newEmulatorServer(t)
+
+	seedJobs(t, srv, []seedJob{
+		{id: "job-a", title: "Python scraper", publishTime: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC), budget: 500, jobType: 2},
+	})
+
+	job, raw, err := srv.getJobByID(context.Background(), "job-a")
+	if err != nil {
+		t.Fatalf("getJobByID failed: %v", err)
+	}
+	if job.Title != "Python scraper" || raw["state"] == nil {
+		t.Fatalf("unexpected job or raw document: %+v %v", job, raw)
+	}
+
+	if _, _, err := srv.getJobByID(context.Background(), "job-x"); !errors.Is(err, errJobNotFound) {
+		t.Fatalf("expected errJobNotFound, got %v", err)
+	}
+}
+
+func TestIsDeadlineExceeded(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: context.DeadlineExceeded, want: true},
+		{err: fmt.Errorf("iterating: %w", context.DeadlineExceeded), want: true},
+		{err: status.Error(codes.DeadlineExceeded, "deadline"), want: true},
+		{err: status.Error(codes.Unavailable, "unavailable"), want: false},
+		{err: context.Canceled, want: false},
+	}
+	for _, tc := range tests {
+		if got := isDeadlineExceeded(tc.err); got != tc.want {
+			t.Fatalf("isDeadlineExceeded(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestMissingIndexLink(t *testing.T) {
+	const link = "https://console.firebase.google.com/v1/r/project/demo/firestore/indexes?create_composite=abc"
+	indexErr := status.Error(codes.FailedPrecondition, "The query requires an index. You can create it here: "+link)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantOK   bool
+		wantLink string
+	}{
+		{name: "nil", err: nil},
+		{name: "index error", err: indexErr, wantOK: true, wantLink: link},
+		{name: "wrapped index error", err: fmt.Errorf("firestore query failed: %w", indexErr), wantOK: true, wantLink: link},
+		{name: "index error without link", err: status.Error(codes.FailedPrecondition, "The query requires an index."), wantOK: true, wantLink: "(no link in error)"},
+		{name: "other precondition", err: status.Error(codes.FailedPrecondition, "transaction aborted")},
+		{name: "other code", err: status.Error(codes.Internal, "The query requires an index.")},
+	}
+	for _, tc := range tests {
+		link, ok := missingIndexLink(tc.err)
+		if ok != tc.wantOK || link != tc.wantLink {
+			t.Fatalf("%s: missingIndexLink() = (%q, %v), want (%q, %v)", tc.name, link, ok, tc.wantLink, tc.wantOK)
+		}
+	}
+}
+
+func TestDocsCacheMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/openapi.json", docsCacheMiddleware(docsCacheMaxAge), func(c *gin.Context) {
+		c.String(http.StatusOK, "{}")
+	})
+	router.GET("/jobs", func(c *gin.Context) {
+		c.String(http.StatusOK, "{}")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Fatalf("expected docs cache header, got %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "" {
+		t.Fatalf("expected no cache header outside docs routes, got %q", got)
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// skillSampleSize is how many recent jobs feed the skill frequency map
+	skillSampleSize = 500
+	// defaultSkillRefreshInterval is how often the skill map is rebuilt
+	defaultSkillRefreshInterval = time.Hour
+	defaultSkillSuggestLimit    = 10
+	maxSkillSuggestLimit        = 50
+)
+
+// SkillSuggestion is one autocomplete entry.
+type SkillSuggestion struct {
+	Skill string `json:"skill"`
+	Count int    `json:"count"`
+}
+
+// SkillSuggestResponse is returned by GET /skills/suggest.
+type SkillSuggestResponse struct {
+	Success     bool              `json:"success"`
+	Data        []SkillSuggestion `json:"data"`
+	Count       int               `json:"count"`
+	SampledAt   string            `json:"sampled_at,omitempty"`
+	LastUpdated string            `json:"last_updated"`
+}
+
+// skillIndex holds skill frequencies from a sample of recent jobs.
+type skillIndex struct {
+	mu        sync.RWMutex
+	counts    map[string]int    // normalized label -> jobs carrying it
+	labels    map[string]string // normalized label -> display label
+	sampledAt time.Time
+}
+
+// replace swaps in freshly counted skills.
+func (idx *skillIndex) replace(counts map[string]int, labels map[string]string, at time.Time) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.counts = counts
+	idx.labels = labels
+	idx.sampledAt = at
+}
+
+func (idx *skillIndex) ready() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return !idx.sampledAt.IsZero()
+}
+
+// suggest returns skills starting with prefix, most frequent first.
+func (idx *skillIndex) suggest(prefix string, limit int) ([]SkillSuggestion, time.Time) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	matches := make([]SkillSuggestion, 0)
+	for key, count := range idx.counts {
+		if strings.HasPrefix(key, prefix) {
+			matches = append(matches, SkillSuggestion{Skill: idx.labels[key], Count: count})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Count != matches[j].Count {
+			return matches[i].Count > matches[j].Count
+		}
+		return matches[i].Skill < matches[j].Skill
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, idx.sampledAt
+}
+
+// countSkills tallies distinct skills per job. The first spelling seen for a
+// skill becomes its display label.
+func countSkills(jobs []JobRecord) (map[string]int, map[string]string) {
+	counts := make(map[string]int)
+	labels := make(map[string]string)
+	for _, job := range jobs {
+		seen := make(map[string]struct{}, len(job.Skills))
+		for _, skill := range job.Skills {
+			label := strings.TrimSpace(skill)
+			key := strings.ToLower(label)
+			if key == "" {
+				continue
+			}
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			counts[key]++
+			if _, ok := labels[key]; !ok {
+				labels[key] = label
+			}
+		}
+	}
+	return counts, labels
+}
+
+// refreshSkillIndex samples the most recent jobs and rebuilds the skill map.
+func (s *Server) refreshSkillIndex(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	query := s.client.Collection(s.collectionName).OrderBy("publishTime", firestore.Desc).Limit(skillSampleSize)
+	if s.projectFields {
+		query = query.Select(jobProjectionPaths...)
+	}
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	var jobs []JobRecord
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("skill sample query failed: %w", err)
+		}
+		records, err := transformDocument(doc)
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, records...)
+	}
+
+	counts, labels := countSkills(jobs)
+	s.skills.replace(counts, labels, time.Now().UTC())
+	log.Printf("🧠 Skill index refreshed: %d skills from %d jobs", len(counts), len(jobs))
+	return nil
+}
+
+// runSkillIndexRefresher rebuilds the skill map every interval until shutdown.
+func (s *Server) runSkillIndexRefresher(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.refreshSkillIndex(s.rootCtx); err != nil {
+			log.Printf("⚠️ Skill index refresh failed: %v", err)
+		}
+		select {
+		case <-s.rootCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// handleSkillsSuggest returns skill labels for autocomplete.
+// @Summary Suggest skills
+// @Description Distinct skill labels from recent jobs starting with `q`, ranked by how many sampled jobs carry them. The sample is refreshed periodically, so responses may be cached by clients.
+// @Tags jobs
+// @Produce json
+// @Param q query string true "Skill prefix (case-insensitive)" example(py)
+// @Param limit query int false "Maximum suggestions (1-50)" default(10)
+// @Success 200 {object} SkillSuggestResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Failure 503 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /skills/suggest [get]
+func (s *Server) handleSkillsSuggest(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		respondError(c, http.StatusBadRequest, "Query parameter 'q' is required")
+		return
+	}
+
+	limit := defaultSkillSuggestLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxSkillSuggestLimit {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSkillSuggestLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	// The background refresher may not have finished its first pass yet
+	if !s.skills.ready() {
+		if err := s.refreshSkillIndex(c.Request.Context()); err != nil {
+			respondError(c, http.StatusServiceUnavailable, "Skill index is not available yet")
+			return
+		}
+	}
+
+	suggestions, sampledAt := s.skills.suggest(prefix, limit)
+
+	// Skills change slowly; let clients reuse suggestions for a while
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.JSON(http.StatusOK, SkillSuggestResponse{
+		Success:     true,
+		Data:        suggestions,
+		Count:       len(suggestions),
+		SampledAt:   sampledAt.Format(time.RFC3339),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
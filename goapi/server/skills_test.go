@@ -0,0 +1,40 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSkillIndexSuggest(t *testing.T) {
+	jobs := []JobRecord{
+		{ID: "a", Skills: []string{"Python", "Django", "python"}},
+		{ID: "b", Skills: []string{"python", "PyTorch"}},
+		{ID: "c", Skills: []string{"PyTorch", "Pandas", "Python"}},
+		{ID: "d", Skills: []string{"Pandas", " "}},
+	}
+
+	counts, labels := countSkills(jobs)
+	if counts["python"] != 3 || labels["python"] != "Python" {
+		t.Fatalf("expected python counted once per job with first label, got %d %q", counts["python"], labels["python"])
+	}
+
+	var idx skillIndex
+	if idx.ready() {
+		t.Fatalf("empty index must not be ready")
+	}
+	idx.replace(counts, labels, time.Now())
+
+	got, _ := idx.suggest("PY", 10)
+	want := []SkillSuggestion{{Skill: "Python", Count: 3}, {Skill: "PyTorch", Count: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("suggest(PY) = %v, want %v", got, want)
+	}
+
+	if got, _ := idx.suggest("p", 2); len(got) != 2 || got[0].Skill != "Python" || got[1].Skill != "Pandas" {
+		t.Fatalf("expected top 2 by frequency then name, got %v", got)
+	}
+	if got, _ := idx.suggest("rust", 10); len(got) != 0 {
+		t.Fatalf("expected no suggestions, got %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// SkipSummary counts documents dropped while assembling a debug /jobs
+// response, by reason.
+type SkipSummary struct {
+	TransformError int64 `json:"transform_error"`
+	FilteredOut    int64 `json:"filtered_out"`
+	Duplicate      int64 `json:"duplicate"`
+}
+
+// skipStats accumulates skip counts across the concurrent stages of a query.
+type skipStats struct {
+	transformError atomic.Int64
+	filteredOut    atomic.Int64
+	duplicate      atomic.Int64
+}
+
+func (s *skipStats) summary() *SkipSummary {
+	return &SkipSummary{
+		TransformError: s.transformError.Load(),
+		FilteredOut:    s.filteredOut.Load(),
+		Duplicate:      s.duplicate.Load(),
+	}
+}
+
+type skipStatsKey struct{}
+
+// withSkipStats attaches a skip counter to ctx for debug requests.
+func withSkipStats(ctx context.Context) (context.Context, *skipStats) {
+	stats := &skipStats{}
+	return context.WithValue(ctx, skipStatsKey{}, stats), stats
+}
+
+// skipStatsFrom returns the skip counter in ctx, or nil outside debug mode.
+func skipStatsFrom(ctx context.Context) *skipStats {
+	stats, _ := ctx.Value(skipStatsKey{}).(*skipStats)
+	return stats
+}
+
+// inheritSkipStats copies the skip counter from src onto dst, for contexts
+// derived from the server root rather than the request.
+func inheritSkipStats(dst, src context.Context) context.Context {
+	if src == nil {
+		return dst
+	}
+	if stats := skipStatsFrom(src); stats != nil {
+		return context.WithValue(dst, skipStatsKey{}, stats)
+	}
+	return dst
+}
@@ -0,0 +1,69 @@
+package server
+
+import "strings"
+
+// stemSuffixes are stripped (or rewritten) by stemWord, longest first.
+var stemSuffixes = []struct {
+	suffix      string
+	replacement string
+}{
+	{"ization", "ize"},
+	{"ational", "ate"},
+	{"fulness", "ful"},
+	{"ousness", "ous"},
+	{"iveness", "ive"},
+	{"ations", "ate"},
+	{"ation", "ate"},
+	{"ments", ""},
+	{"ment", ""},
+	{"ness", ""},
+	{"ings", ""},
+	{"ing", ""},
+	{"ers", ""},
+	{"er", ""},
+	{"ed", ""},
+	{"ly", ""},
+}
+
+// minStemLength keeps short words like "user" or "api" intact.
+const minStemLength = 3
+
+// stemWord is a lightweight Porter-style stemmer: it drops plurals, one common
+// derivational suffix, a trailing "e" and a doubled final consonant, so
+// "developer", "developing" and "development" all become "develop".
+func stemWord(word string) string {
+	if len(word) <= minStemLength {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+
+	for _, rule := range stemSuffixes {
+		if !strings.HasSuffix(word, rule.suffix) {
+			continue
+		}
+		stem := word[:len(word)-len(rule.suffix)] + rule.replacement
+		if len(stem) >= minStemLength {
+			word = stem
+		}
+		break
+	}
+
+	if len(word) > minStemLength && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+
+	if n := len(word); n > minStemLength && word[n-1] == word[n-2] && !strings.ContainsRune("aeiouylsz", rune(word[n-1])) {
+		word = word[:n-1]
+	}
+
+	return word
+}
@@ -0,0 +1,47 @@
+package server
+
+import "testing"
+
+func TestStemWordPairs(t *testing.T) {
+	pairs := [][2]string{
+		{"develop", "developer"},
+		{"develop", "developing"},
+		{"develop", "developments"},
+		{"scrape", "scraping"},
+		{"run", "running"},
+		{"automate", "automation"},
+		{"design", "designers"},
+		{"company", "companies"},
+	}
+	for _, pair := range pairs {
+		if a, b := stemWord(pair[0]), stemWord(pair[1]); a != b {
+			t.Fatalf("stemWord(%q) = %q, stemWord(%q) = %q; want equal", pair[0], a, pair[1], b)
+		}
+	}
+
+	for _, word := range []string{"api", "user", "class", "go"} {
+		if got := stemWord(word); got != word {
+			t.Fatalf("stemWord(%q) = %q, want unchanged", word, got)
+		}
+	}
+}
+
+func TestSearchStemmingIsOptIn(t *testing.T) {
+	job := JobRecord{Title: "Senior developer for scraping pipelines"}
+
+	opts := FilterOptions{}
+	if err := opts.ApplySearchQuery("develop AND scrape"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if matchesSearchExpression(&job, opts.SearchExpression) {
+		t.Fatalf("expected exact matching by default")
+	}
+
+	opts = FilterOptions{StemSearch: true}
+	if err := opts.ApplySearchQuery("develop AND scrape"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !matchesSearchExpression(&job, opts.SearchExpression) {
+		t.Fatalf("expected stemmed terms to match")
+	}
+}
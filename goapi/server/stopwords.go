@@ -0,0 +1,67 @@
+package server
+
+import (
+	"sort"
+	"strings"
+)
+
+// defaultSearchStopwords is the built-in list SEARCH_STOPWORDS=default enables.
+// These words appear in most postings and only dilute matches: "the", "a",
+// "an", "and", "for", "of", "to", "in", "on", "with", "job" and "need".
+var defaultSearchStopwords = []string{"the", "a", "an", "and", "for", "of", "to", "in", "on", "with", "job", "need"}
+
+// searchStopwords is the active set. It starts empty so search terms are
+// matched as typed unless SEARCH_STOPWORDS opts in.
+var searchStopwords = map[string]struct{}{}
+
+func stopwordSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			set[word] = struct{}{}
+		}
+	}
+	return set
+}
+
+// ConfigureSearchStopwords sets the stopword list from SEARCH_STOPWORDS:
+// empty or "off" (or "none") disables removal, "default" (or "on") enables
+// the built-in list and anything else is a comma-separated list to use.
+func ConfigureSearchStopwords(raw string) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "off", "none", "false":
+		searchStopwords = map[string]struct{}{}
+	case "default", "on", "true":
+		searchStopwords = stopwordSet(defaultSearchStopwords)
+	default:
+		searchStopwords = stopwordSet(parseCSV(raw))
+	}
+}
+
+// stopwordList returns the active stopwords in sorted order.
+func stopwordList() []string {
+	words := make([]string, 0, len(searchStopwords))
+	for word := range searchStopwords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// removeStopwords drops unquoted stopword terms. Phrases are kept verbatim so
+// "\"the agency\"" still requires the full phrase.
+func removeStopwords(tokens []searchToken) []searchToken {
+	if len(searchStopwords) == 0 {
+		return tokens
+	}
+	filtered := make([]searchToken, 0, len(tokens))
+	for _, tok := range tokens {
+		if tok.kind == tokenTerm {
+			if _, stop := searchStopwords[tok.value]; stop {
+				continue
+			}
+		}
+		filtered = append(filtered, tok)
+	}
+	return filtered
+}
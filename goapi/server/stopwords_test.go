@@ -0,0 +1,45 @@
+package server
+
+import "testing"
+
+func TestSearchStopwords(t *testing.T) {
+	defer ConfigureSearchStopwords("off")
+
+	matches := func(query string, job JobRecord) bool {
+		expr, err := ParseSearchQuery(query)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", query, err)
+		}
+		return matchesSearchExpression(&job, expr)
+	}
+	golang := JobRecord{Title: "Golang backend developer"}
+	agency := JobRecord{Title: "Looking for the agency"}
+
+	if matches("the golang job", golang) {
+		t.Fatalf("expected stopwords to be required by default")
+	}
+
+	ConfigureSearchStopwords("default")
+	if !matches("the golang job", golang) {
+		t.Fatalf("expected stopwords to be ignored")
+	}
+	if matches("\"the golang\"", golang) {
+		t.Fatalf("expected quoted phrase to keep its stopwords")
+	}
+	if !matches("\"the agency\"", agency) {
+		t.Fatalf("expected quoted phrase to match verbatim")
+	}
+	if !matches("python OR the", agency) {
+		t.Fatalf("expected an all-but-invalid query to keep its stopwords")
+	}
+
+	ConfigureSearchStopwords("off")
+	if matches("the golang job", golang) {
+		t.Fatalf("expected stopwords to be required when disabled")
+	}
+
+	ConfigureSearchStopwords("golang, Backend")
+	if got := stopwordList(); len(got) != 2 || got[0] != "backend" || got[1] != "golang" {
+		t.Fatalf("unexpected custom stopwords: %v", got)
+	}
+}
@@ -2,16 +2,46 @@ package server
 
 import (
 	"fmt"
+	"log"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"cloud.google.com/go/firestore"
 )
 
+// jobProjectionPaths lists every document path transformDocumentData reads.
+// Keep in sync with the transform when it starts reading new fields.
+var jobProjectionPaths = []string{
+	"state.jobDetails.job",
+	"state.jobDetails.buyer",
+	"state.job",
+	"job",
+	"url",
+	"publishTime",
+	"scrape_metadata",
+	"last_scraped",
+	"updated_at",
+}
+
+// lastVisitedPaths are the document paths that may carry the last visit
+// time, in order of preference. Older scrapers wrote last_scraped or
+// updated_at instead of scrape_metadata.last_visited_at.
+var lastVisitedPaths = [][]string{
+	{"scrape_metadata", "last_visited_at"},
+	{"scrape_metadata", "last_scraped"},
+	{"scrape_metadata", "updated_at"},
+	{"last_scraped"},
+	{"updated_at"},
+}
+
 type jobSource struct {
-	data  map[string]interface{}
-	buyer map[string]interface{}
+	data        map[string]interface{}
+	buyer       map[string]interface{}
+	fromSimilar bool
 }
 
 // transformDocument converts a Firestore snapshot into one or more JobRecords.
@@ -25,7 +55,30 @@ func DebugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 }
 
 func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error) {
-	raw := doc.Data()
+	return transformDocumentData(doc.Data(), doc.Ref.ID)
+}
+
+// TransformJobListDocument converts a job_list document, a single search
+// result saved by the scraper, for diagnostics. Search results carry no buyer
+// section, so Buyer stays empty.
+func TransformJobListDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error) {
+	return transformJobListData(doc.Data(), doc.Ref.ID)
+}
+
+func transformJobListData(raw map[string]interface{}, docID string) ([]JobRecord, error) {
+	if raw == nil {
+		return nil, fmt.Errorf("empty document data")
+	}
+	rec := buildJobRecord(raw, nil, raw, docID, false, "", "")
+	if rec == nil {
+		return nil, fmt.Errorf("unable to build job record")
+	}
+	return []JobRecord{*rec}, nil
+}
+
+// transformDocumentData converts raw document data into JobRecords. docID is
+// used as the fallback job ID when the payload carries no uid.
+func transformDocumentData(raw map[string]interface{}, docID string) ([]JobRecord, error) {
 	if raw == nil {
 		return nil, fmt.Errorf("empty document data")
 	}
@@ -36,7 +89,7 @@ func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 	}
 
 	jobState := getMap(stateMap, "job")
-	isPrivate, privacyReason := detectPrivacy(jobState)
+	isPrivate, privacyStatus, privacyReason := detectPrivacy(jobState)
 
 	primaryCandidates := []map[string]interface{}{
 		getMap(stateMap, "jobDetails", "job"),
@@ -66,13 +119,13 @@ func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 	if len(sources) == 0 {
 		similarJobs := extractMapSlice(stateMap, "job", "errorResponse", "similarJobs")
 		for _, jobMap := range similarJobs {
-			sources = append(sources, jobSource{data: jobMap})
+			sources = append(sources, jobSource{data: jobMap, fromSimilar: true})
 		}
 	}
 
 	if len(sources) == 0 {
 		if isPrivate {
-			placeholder := buildPrivatePlaceholder(raw, doc.Ref.ID, privacyReason)
+			placeholder := buildPrivatePlaceholder(raw, docID, privacyStatus, privacyReason)
 			if placeholder != nil {
 				return []JobRecord{*placeholder}, nil
 			}
@@ -87,7 +140,7 @@ func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 			continue
 		}
 
-		rec := buildJobRecord(src.data, src.buyer, raw, doc.Ref.ID, isPrivate, privacyReason)
+		rec := buildJobRecord(src.data, src.buyer, raw, docID, isPrivate, privacyStatus, privacyReason)
 		if rec == nil || rec.ID == "" {
 			continue
 		}
@@ -95,6 +148,7 @@ func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 			continue
 		}
 		seen[rec.ID] = struct{}{}
+		rec.FromSimilar = src.fromSimilar
 		records = append(records, *rec)
 	}
 
@@ -105,7 +159,32 @@ func debugTransformDocument(doc *firestore.DocumentSnapshot) ([]JobRecord, error
 	return records, nil
 }
 
-func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface{}, docMap map[string]interface{}, fallbackID string, isPrivate bool, privacyReason string) *JobRecord {
+// similarJobRecords extracts the similarJobs listed on a private job's error
+// page. They are public jobs in their own right, so no privacy is inherited.
+func similarJobRecords(raw map[string]interface{}, docID string) []JobRecord {
+	similarJobs := extractMapSlice(raw, "state", "job", "errorResponse", "similarJobs")
+	records := make([]JobRecord, 0, len(similarJobs))
+	for _, jobMap := range similarJobs {
+		rec := buildJobRecord(jobMap, nil, raw, docID, false, "", "")
+		if rec == nil || rec.ID == "" || rec.ID == docID {
+			continue
+		}
+		rec.FromSimilar = true
+		records = append(records, *rec)
+	}
+	return records
+}
+
+func hasPrivateRecord(records []JobRecord) bool {
+	for _, rec := range records {
+		if rec.IsPrivate {
+			return true
+		}
+	}
+	return false
+}
+
+func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface{}, docMap map[string]interface{}, fallbackID string, isPrivate bool, privacyStatus string, privacyReason string) *JobRecord {
 	id := firstNonEmpty(
 		getString(jobMap, "uid"),
 		fallbackID,
@@ -160,7 +239,7 @@ func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface
 		[]string{"createdOn"},
 	)
 
-	lastVisited := firstTime(docMap, []string{"scrape_metadata", "last_visited_at"})
+	lastVisited := firstTime(docMap, lastVisitedPaths...)
 
 	url := getString(docMap, "url")
 	if url == "" {
@@ -181,7 +260,7 @@ func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface
 	tierText := getString(jobMap, "tierText")
 
 	createdOn := firstTime(jobMap, []string{"createdOn"})
-	publishTime := firstTime(jobMap, []string{"publishTime"})
+	publishTime := checkFuturePublishTime(id, firstTime(jobMap, []string{"publishTime"}), time.Now().UTC())
 
 	var isContractToHire *bool
 	if val, ok := extractBool(jobMap, "contractToHire"); ok {
@@ -209,9 +288,8 @@ func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface
 	}
 
 	var recno *int64
-	if val, ok := extractInt(jobMap, "recno"); ok {
-		recno64 := int64(val)
-		recno = &recno64
+	if val, ok := extractInt64(jobMap, "recno"); ok {
+		recno = &val
 	}
 
 	qualifications := buildQualifications(getMap(jobMap, "qualifications"))
@@ -241,6 +319,7 @@ func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface
 		DurationLabel:        duration,
 		Engagement:           engagement,
 		IsPrivate:            isPrivate,
+		PrivacyStatus:        privacyStatus,
 		PrivacyReason:        privacyReason,
 		Ciphertext:           ciphertext,
 		Workload:             workload,
@@ -255,30 +334,37 @@ func buildJobRecord(jobMap map[string]interface{}, buyerMap map[string]interface
 		WeeklyRetainerBudget: weeklyRetainerBudget,
 		Occupations:          occupations,
 		Recno:                recno,
+		QualityScore:         qualityScore(buyer),
 	}
 }
 
 func buildBudget(job map[string]interface{}) (*BudgetInfo, *HourlyBudget) {
 	var fixedAmount *float64
 	var currency string
+	var secondary *SecondaryBudget
 
-	if budgetMap := getMap(job, "budget"); budgetMap != nil {
-		if amount, ok := extractFloat(budgetMap, "amount"); ok {
-			v := amount
-			fixedAmount = &v
-		}
-		if curr := getString(budgetMap, "currencyCode"); curr != "" {
-			currency = curr
-		}
-	}
+	budgetAmount, budgetCurrency := budgetMapValues(getMap(job, "budget"))
+	amountAmount, amountCurrency := budgetMapValues(getMap(job, "amount"))
 
-	if amountMap := getMap(job, "amount"); amountMap != nil {
-		if amount, ok := extractFloat(amountMap, "amount"); ok {
-			v := amount
-			fixedAmount = &v
+	if budgetCurrency != "" && amountCurrency != "" && !strings.EqualFold(budgetCurrency, amountCurrency) {
+		// Merging would pair one map's amount with the other's currency, so
+		// keep the preferred map whole and surface the other alongside it
+		if budgetCurrencySource == BudgetSourceBudget {
+			fixedAmount, currency = budgetAmount, budgetCurrency
+			secondary = &SecondaryBudget{FixedAmount: amountAmount, Currency: amountCurrency, Source: BudgetSourceAmount}
+		} else {
+			fixedAmount, currency = amountAmount, amountCurrency
+			secondary = &SecondaryBudget{FixedAmount: budgetAmount, Currency: budgetCurrency, Source: BudgetSourceBudget}
+		}
+		log.Printf("⚠️ Budget currency conflict (budget=%s, amount=%s); preferring %s", budgetCurrency, amountCurrency, budgetCurrencySource)
+	} else {
+		// The amount map wins field by field, as it is the more specific one
+		fixedAmount, currency = budgetAmount, budgetCurrency
+		if amountAmount != nil {
+			fixedAmount = amountAmount
 		}
-		if curr := getString(amountMap, "currencyCode"); curr != "" {
-			currency = curr
+		if amountCurrency != "" {
+			currency = amountCurrency
 		}
 	}
 
@@ -303,6 +389,7 @@ func buildBudget(job map[string]interface{}) (*BudgetInfo, *HourlyBudget) {
 		budget = &BudgetInfo{
 			FixedAmount: fixedAmount,
 			Currency:    currency,
+			Secondary:   secondary,
 		}
 	}
 
@@ -318,8 +405,99 @@ func buildBudget(job map[string]interface{}) (*BudgetInfo, *HourlyBudget) {
 	return budget, hourly
 }
 
-func buildPrivatePlaceholder(docMap map[string]interface{}, fallbackID string, reason string) *JobRecord {
-	lastVisited := firstTime(docMap, []string{"scrape_metadata", "last_visited_at"})
+// Actions for publish times beyond futurePublishWindow, set by
+// FUTURE_PUBLISH_ACTION.
+const (
+	FuturePublishKeep = "keep" // log and keep the time
+	FuturePublishNull = "null" // log and drop the time so the job sorts last
+)
+
+// futurePublishWindow is how far ahead of now a publish time may be before it
+// is treated as a data bug; scraper clock skew stays well inside it.
+// Override with FUTURE_PUBLISH_WINDOW via ConfigureFuturePublishWindow.
+var futurePublishWindow = time.Hour
+
+// futurePublishAction is applied to publish times beyond the window.
+var futurePublishAction = FuturePublishKeep
+
+// ConfigureFuturePublishWindow sets the tolerated future skew, e.g. "30m".
+func ConfigureFuturePublishWindow(raw string) error {
+	window, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	if window < 0 {
+		return fmt.Errorf("window must not be negative, got %v", window)
+	}
+	futurePublishWindow = window
+	return nil
+}
+
+// ConfigureFuturePublishAction sets what happens to publish times beyond the
+// window: "keep" (the default) or "null".
+func ConfigureFuturePublishAction(raw string) error {
+	switch action := strings.ToLower(strings.TrimSpace(raw)); action {
+	case FuturePublishKeep, FuturePublishNull:
+		futurePublishAction = action
+		return nil
+	default:
+		return fmt.Errorf("invalid future publish action: %s (use keep or null)", raw)
+	}
+}
+
+// checkFuturePublishTime logs publish times more than futurePublishWindow
+// after now and, with FuturePublishNull, drops them so they cannot lead
+// publish_time_desc results.
+func checkFuturePublishTime(id string, publishTime *time.Time, now time.Time) *time.Time {
+	if publishTime == nil || !publishTime.After(now.Add(futurePublishWindow)) {
+		return publishTime
+	}
+	ahead := publishTime.Sub(now).Round(time.Minute)
+	if futurePublishAction == FuturePublishNull {
+		log.Printf("⚠️ Job %s publish time %s is %v in the future; dropping it", id, publishTime.Format(time.RFC3339), ahead)
+		return nil
+	}
+	log.Printf("⚠️ Job %s publish time %s is %v in the future", id, publishTime.Format(time.RFC3339), ahead)
+	return publishTime
+}
+
+// budgetMapValues reads the amount and currency code from a budget-shaped map.
+func budgetMapValues(m map[string]interface{}) (*float64, string) {
+	if m == nil {
+		return nil, ""
+	}
+	var amount *float64
+	if v, ok := extractFloat(m, "amount"); ok {
+		amount = &v
+	}
+	return amount, getString(m, "currencyCode")
+}
+
+// Budget currency sources, named after the document maps they come from.
+const (
+	BudgetSourceAmount = "amount"
+	BudgetSourceBudget = "budget"
+)
+
+// budgetCurrencySource is the map kept as the primary budget when the budget
+// and amount maps disagree on currency. Override with BUDGET_CURRENCY_SOURCE
+// via ConfigureBudgetCurrencySource.
+var budgetCurrencySource = BudgetSourceAmount
+
+// ConfigureBudgetCurrencySource sets which map wins a currency conflict:
+// "amount" (the default) or "budget".
+func ConfigureBudgetCurrencySource(raw string) error {
+	switch source := strings.ToLower(strings.TrimSpace(raw)); source {
+	case BudgetSourceAmount, BudgetSourceBudget:
+		budgetCurrencySource = source
+		return nil
+	default:
+		return fmt.Errorf("invalid budget currency source: %s (use amount or budget)", raw)
+	}
+}
+
+func buildPrivatePlaceholder(docMap map[string]interface{}, fallbackID string, status string, reason string) *JobRecord {
+	lastVisited := firstTime(docMap, lastVisitedPaths...)
 	url := getString(docMap, "url")
 
 	return &JobRecord{
@@ -327,26 +505,76 @@ func buildPrivatePlaceholder(docMap map[string]interface{}, fallbackID string, r
 		URL:           url,
 		LastVisitedAt: lastVisited,
 		IsPrivate:     true,
+		PrivacyStatus: status,
 		PrivacyReason: reason,
 	}
 }
 
-func detectPrivacy(jobState map[string]interface{}) (bool, string) {
+type privacyStatusInfo struct {
+	status string
+	reason string
+}
+
+// knownPrivacyStatuses describes the errorResponse status codes Upwork uses
+// for jobs that can't be viewed.
+var knownPrivacyStatuses = map[int]privacyStatusInfo{
+	401: {status: "requires_login", reason: "This job requires login to view (401)."},
+	403: {status: "private", reason: "This job is private or restricted (403)."},
+	404: {status: "removed", reason: "This job has been removed (404)."},
+}
+
+// privacyStatusCodes is the set of status codes treated as private.
+// Override with PRIVACY_STATUS_CODES via ConfigurePrivacyStatusCodes.
+var privacyStatusCodes = map[int]struct{}{401: {}, 403: {}, 404: {}}
+
+// ConfigurePrivacyStatusCodes replaces the set of errorResponse status codes
+// that mark a job as private from a comma-separated list such as "403,404".
+func ConfigurePrivacyStatusCodes(raw string) error {
+	codes := make(map[int]struct{})
+	for _, token := range parseCSV(raw) {
+		code, err := strconv.Atoi(token)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("invalid privacy status code: %s", token)
+		}
+		codes[code] = struct{}{}
+	}
+	if len(codes) == 0 {
+		return fmt.Errorf("at least one privacy status code is required")
+	}
+	privacyStatusCodes = codes
+	return nil
+}
+
+func detectPrivacy(jobState map[string]interface{}) (bool, string, string) {
 	if jobState == nil {
-		return false, ""
+		return false, "", ""
 	}
 
 	if errResp := getMap(jobState, "errorResponse"); errResp != nil {
-		if status, ok := extractInt(errResp, "status"); ok && status == 403 {
-			reason := strings.TrimSpace(getString(errResp, "text"))
-			if reason == "" || strings.HasPrefix(reason, "{") {
-				reason = "This job is private or restricted (403)."
+		code, ok := extractInt(errResp, "status")
+		if !ok {
+			return false, "", ""
+		}
+		if _, tracked := privacyStatusCodes[code]; !tracked {
+			return false, "", ""
+		}
+
+		info, known := knownPrivacyStatuses[code]
+		if !known {
+			info = privacyStatusInfo{
+				status: "unavailable",
+				reason: fmt.Sprintf("This job is unavailable (%d).", code),
 			}
-			return true, reason
 		}
+
+		reason := strings.TrimSpace(getString(errResp, "text"))
+		if reason == "" || strings.HasPrefix(reason, "{") {
+			reason = info.reason
+		}
+		return true, info.status, reason
 	}
 
-	return false, ""
+	return false, "", ""
 }
 
 func budgetFromAmount(m map[string]interface{}) *BudgetInfo {
@@ -378,9 +606,7 @@ func buildBuyer(buyer map[string]interface{}) *BuyerInfo {
 		if city := getString(loc, "city"); city != "" {
 			info.City = strings.TrimSpace(city)
 		}
-		if tz := getString(loc, "countryTimezone"); tz != "" {
-			info.Timezone = tz
-		}
+		info.Timezone = extractTimezone(loc, info.Country)
 	}
 
 	if stats := getMap(buyer, "stats"); stats != nil {
@@ -410,6 +636,9 @@ func buildBuyer(buyer map[string]interface{}) *BuyerInfo {
 	}
 
 	if company := getMap(buyer, "company"); company != nil {
+		if name := getString(company, "name"); name != "" {
+			info.CompanyName = name
+		}
 		if industry := getString(company, "industry"); industry != "" {
 			info.CompanyIndustry = industry
 		}
@@ -432,7 +661,7 @@ func buildBuyer(buyer map[string]interface{}) *BuyerInfo {
 	if info.PaymentVerified == nil && info.Country == "" && info.City == "" && info.Timezone == "" &&
 		info.TotalSpent == nil && info.TotalAssignments == nil && info.TotalJobsWithHires == nil &&
 		info.ActiveAssignments == nil && info.FeedbackCount == nil && info.TotalHours == nil &&
-		info.Score == nil && info.CompanyIndustry == "" && info.CompanySize == nil &&
+		info.Score == nil && info.CompanyName == "" && info.CompanyIndustry == "" && info.CompanySize == nil &&
 		info.ContractDate == nil && info.OpenJobsCount == nil {
 		return nil
 	}
@@ -464,6 +693,9 @@ func buildClientActivity(activity map[string]interface{}) *ClientActivity {
 	}
 	if last := getString(activity, "lastBuyerActivity"); last != "" {
 		result.LastBuyerActivity = last
+		if parsed, err := parseFlexibleTime(last); err == nil {
+			result.LastBuyerActivityAt = &parsed
+		}
 	}
 
 	if result.TotalApplicants == nil && result.TotalHired == nil && result.TotalInvitedToInterview == nil && result.UnansweredInvites == nil && result.InvitationsSent == nil && result.LastBuyerActivity == "" {
@@ -473,6 +705,21 @@ func buildClientActivity(activity map[string]interface{}) *ClientActivity {
 	return result
 }
 
+// extractTimezone applies one precedence for buyer and job locations: the
+// city-level timezone, then countryTimezone, then the country's primary zone.
+func extractTimezone(loc map[string]interface{}, country string) string {
+	if tz := strings.TrimSpace(getString(loc, "timezone")); tz != "" {
+		return tz
+	}
+	if tz := strings.TrimSpace(getString(loc, "countryTimezone")); tz != "" {
+		return tz
+	}
+	if country == "" {
+		return ""
+	}
+	return countryPrimaryTimezones[canonicalCountry(country)]
+}
+
 func buildJobLocation(job map[string]interface{}) *JobLocation {
 	loc := getMap(job, "jobLocation")
 	if loc == nil {
@@ -489,11 +736,7 @@ func buildJobLocation(job map[string]interface{}) *JobLocation {
 	if city := getString(loc, "city"); city != "" {
 		location.City = strings.TrimSpace(city)
 	}
-	if tz := getString(loc, "timezone"); tz != "" {
-		location.Timezone = tz
-	} else if tz := getString(loc, "countryTimezone"); tz != "" {
-		location.Timezone = tz
-	}
+	location.Timezone = extractTimezone(loc, location.Country)
 
 	if location.Country == "" && location.City == "" && location.Timezone == "" {
 		return nil
@@ -636,6 +879,22 @@ func applyFilters(job *JobRecord, opts FilterOptions) bool {
 		}
 	}
 
+	if opts.HasCategory != nil && (job.Category != nil) != *opts.HasCategory {
+		return false
+	}
+
+	if opts.RequireTitle && strings.TrimSpace(job.Title) == "" {
+		return false
+	}
+
+	if opts.HasHourly != nil && hasHourlyRate(job) != *opts.HasHourly {
+		return false
+	}
+
+	if opts.HasFixed != nil && hasFixedBudget(job) != *opts.HasFixed {
+		return false
+	}
+
 	if len(opts.BudgetRanges) > 0 {
 		if !matchesBudgetRanges(job, opts.BudgetRanges) {
 			return false
@@ -648,12 +907,54 @@ func applyFilters(job *JobRecord, opts FilterOptions) bool {
 		}
 	}
 
+	if opts.MinPay != nil && !matchesMinPay(job, *opts.MinPay) {
+		return false
+	}
+
+	if opts.ClientSpendTier != "" && !matchesClientSpendTier(job.Buyer, opts.ClientSpendTier) {
+		return false
+	}
+
 	if len(opts.ClientHiresRanges) > 0 {
 		if job.Buyer == nil || job.Buyer.TotalJobsWithHires == nil || !intRangeContains(*job.Buyer.TotalJobsWithHires, opts.ClientHiresRanges) {
 			return false
 		}
 	}
 
+	if len(opts.ClientReviewsRanges) > 0 {
+		if job.Buyer == nil || job.Buyer.FeedbackCount == nil || !intRangeContains(*job.Buyer.FeedbackCount, opts.ClientReviewsRanges) {
+			return false
+		}
+	}
+
+	if len(opts.CompanySizeRanges) > 0 {
+		if job.Buyer == nil || job.Buyer.CompanySize == nil || !intRangeContains(*job.Buyer.CompanySize, opts.CompanySizeRanges) {
+			return false
+		}
+	}
+
+	if len(opts.InvitationsRanges) > 0 {
+		invites := invitationsCount(job.ClientActivity)
+		if invites == nil || !intRangeContains(*invites, opts.InvitationsRanges) {
+			return false
+		}
+	}
+
+	// Interviews under way signal active hiring; jobs without the count match
+	// neither true nor false
+	if len(opts.InterviewingRanges) > 0 {
+		if job.ClientActivity == nil || job.ClientActivity.TotalInvitedToInterview == nil ||
+			!intRangeContains(*job.ClientActivity.TotalInvitedToInterview, opts.InterviewingRanges) {
+			return false
+		}
+	}
+
+	if len(opts.Industries) > 0 {
+		if !matchesIndustry(job, opts.Industries) {
+			return false
+		}
+	}
+
 	if len(opts.CategoryGroupIDs) > 0 {
 		if job.Category == nil || !stringInSliceFold(job.Category.GroupSlug, opts.CategoryGroupIDs) {
 			return false
@@ -661,7 +962,7 @@ func applyFilters(job *JobRecord, opts FilterOptions) bool {
 	}
 
 	if len(opts.Proposals) > 0 {
-		if job.ProposalsTier == "" || !stringInSliceFold(job.ProposalsTier, opts.Proposals) {
+		if !matchesProposalsTier(job.ProposalsTier, opts.Proposals) {
 			return false
 		}
 	}
@@ -672,6 +973,12 @@ func applyFilters(job *JobRecord, opts FilterOptions) bool {
 		}
 	}
 
+	if len(opts.ExcludedCountries) > 0 {
+		if matchesExcludedCountry(job, opts.ExcludedCountries) {
+			return false
+		}
+	}
+
 	if len(opts.Timezones) > 0 {
 		if !matchesTimezoneFilters(job, opts.Timezones) {
 			return false
@@ -682,6 +989,26 @@ func applyFilters(job *JobRecord, opts FilterOptions) bool {
 		return false
 	}
 
+	if opts.PublishedAfter != nil && (job.PublishTime == nil || !job.PublishTime.After(*opts.PublishedAfter)) {
+		return false
+	}
+
+	if opts.BuyerActiveWithin != nil {
+		if job.ClientActivity == nil || job.ClientActivity.LastBuyerActivityAt == nil {
+			return false
+		}
+		if time.Since(*job.ClientActivity.LastBuyerActivityAt) > *opts.BuyerActiveWithin {
+			return false
+		}
+	}
+
+	// Jobs never visited are kept; only known-stale ones are dropped
+	if opts.MaxStaleness != nil && *opts.MaxStaleness > 0 && job.LastVisitedAt != nil {
+		if time.Since(*job.LastVisitedAt) > *opts.MaxStaleness {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -723,6 +1050,51 @@ func matchesWorkload(workload string, filters []string) bool {
 	return false
 }
 
+// matchesProposalsTier compares tiers after normalizing both sides the way
+// Upwork URLs are parsed, so "50+" and "50-" denote the same tier.
+func matchesProposalsTier(tier string, filters []string) bool {
+	normalizedJob := parseUpworkProposalsTier(tier)
+	if normalizedJob == "" {
+		return false
+	}
+	for _, filter := range filters {
+		if strings.EqualFold(normalizedJob, parseUpworkProposalsTier(filter)) {
+			return true
+		}
+	}
+	return false
+}
+
+// invitationsCount returns how many freelancers the client has invited,
+// falling back to the unanswered invites when the sent count is missing.
+func invitationsCount(activity *ClientActivity) *int {
+	if activity == nil {
+		return nil
+	}
+	if activity.InvitationsSent != nil {
+		return activity.InvitationsSent
+	}
+	return activity.UnansweredInvites
+}
+
+// matchesIndustry compares the buyer's industry against the filters after
+// normalization, so "Tech & IT" matches "tech it".
+func matchesIndustry(job *JobRecord, filters []string) bool {
+	if job.Buyer == nil {
+		return false
+	}
+	industry := normalizeToken(job.Buyer.CompanyIndustry)
+	if industry == "" {
+		return false
+	}
+	for _, filter := range filters {
+		if normalizeToken(filter) == industry {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesBudgetRanges(job *JobRecord, ranges []NumericRange) bool {
 	if len(ranges) == 0 {
 		return true
@@ -739,6 +1111,52 @@ func matchesBudgetRanges(job *JobRecord, ranges []NumericRange) bool {
 	return false
 }
 
+// matchesClientSpendTier checks the buyer's total spend against a
+// client_spend_tier label. Buyers without spend data only match "none".
+func matchesClientSpendTier(buyer *BuyerInfo, tier string) bool {
+	spent := 0.0
+	if buyer != nil && buyer.TotalSpent != nil {
+		spent = *buyer.TotalSpent
+	}
+	if tier == clientSpendTierNone {
+		return spent <= 0
+	}
+	return spent >= clientSpendTiers[tier]
+}
+
+// hasHourlyRate reports whether the job carries a positive hourly rate; the
+// job type code is not consulted since it is sometimes wrong.
+func hasHourlyRate(job *JobRecord) bool {
+	if job.HourlyInfo == nil {
+		return false
+	}
+	return (job.HourlyInfo.Min != nil && *job.HourlyInfo.Min > 0) ||
+		(job.HourlyInfo.Max != nil && *job.HourlyInfo.Max > 0)
+}
+
+// hasFixedBudget reports whether the job carries a positive fixed budget.
+func hasFixedBudget(job *JobRecord) bool {
+	return job.Budget != nil && job.Budget.FixedAmount != nil && *job.Budget.FixedAmount > 0
+}
+
+// matchesMinPay uses OR semantics: a fixed budget of at least minPay, or an
+// hourly range whose top (or only) rate reaches it.
+func matchesMinPay(job *JobRecord, minPay float64) bool {
+	if job.Budget != nil && job.Budget.FixedAmount != nil && *job.Budget.FixedAmount >= minPay {
+		return true
+	}
+	if job.HourlyInfo != nil {
+		rate := job.HourlyInfo.Max
+		if rate == nil {
+			rate = job.HourlyInfo.Min
+		}
+		if rate != nil && *rate >= minPay {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesHourlyRanges(job *JobRecord, ranges []NumericRange) bool {
 	if len(ranges) == 0 {
 		return true
@@ -816,6 +1234,12 @@ func matchSingleLocation(job *JobRecord, filter string) bool {
 	timezones := collectJobTimezones(job)
 	countries := collectJobCountries(job)
 
+	// Country groupings match on membership only; their short names would
+	// otherwise hit timezones by substring ("eu" in "europe/...")
+	if group, ok := countryGroupSets[normalized]; ok {
+		return countryInSet(countries, group)
+	}
+
 	switch normalized {
 	case "africa":
 		if hasTimezonePrefix(timezones, "africa/") {
@@ -832,8 +1256,10 @@ func matchSingleLocation(job *JobRecord, filter string) bool {
 			}
 		}
 	default:
+		// Upwork URLs spell countries out while documents may hold codes
+		want := canonicalCountry(normalized)
 		for _, country := range countries {
-			if strings.EqualFold(country, normalized) {
+			if strings.EqualFold(country, normalized) || canonicalCountry(country) == want {
 				return true
 			}
 		}
@@ -856,6 +1282,36 @@ func matchSingleLocation(job *JobRecord, filter string) bool {
 	return false
 }
 
+// matchesExcludedCountry reports whether the job's location or buyer country
+// is in excluded (canonical countries or country group keys).
+func matchesExcludedCountry(job *JobRecord, excluded []string) bool {
+	countries := collectJobCountries(job)
+	for _, entry := range excluded {
+		if group, ok := countryGroupSets[entry]; ok {
+			if countryInSet(countries, group) {
+				return true
+			}
+			continue
+		}
+		for _, country := range countries {
+			if canonicalCountry(country) == entry {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canonicalCountry maps a country code or English name to a comparable token:
+// known names become their lower-case ISO code, anything else is normalized.
+func canonicalCountry(value string) string {
+	token := normalizeToken(value)
+	if code, ok := countryNameCodes[token]; ok {
+		return code
+	}
+	return token
+}
+
 func matchesTimezoneFilters(job *JobRecord, filters []string) bool {
 	if len(filters) == 0 {
 		return true
@@ -943,6 +1399,144 @@ func normalizeToken(value string) string {
 	return builder.String()
 }
 
+// countryNameCodes maps normalizeToken'd names of common client countries to
+// ISO 3166-1 alpha-2 codes so code and name filters compare equal.
+var countryNameCodes = map[string]string{
+	"unitedstates": "us", "usa": "us", "unitedstatesofamerica": "us",
+	"unitedkingdom": "gb", "uk": "gb", "greatbritain": "gb",
+	"canada": "ca", "australia": "au", "newzealand": "nz",
+	"germany": "de", "france": "fr", "netherlands": "nl", "spain": "es",
+	"italy": "it", "ireland": "ie", "sweden": "se", "switzerland": "ch",
+	"norway": "no", "denmark": "dk", "finland": "fi", "belgium": "be",
+	"austria": "at", "poland": "pl", "portugal": "pt", "israel": "il",
+	"india": "in", "pakistan": "pk", "bangladesh": "bd", "china": "cn",
+	"hongkong": "hk", "japan": "jp", "southkorea": "kr", "singapore": "sg",
+	"philippines": "ph", "indonesia": "id", "vietnam": "vn", "malaysia": "my",
+	"unitedarabemirates": "ae", "uae": "ae", "saudiarabia": "sa", "qatar": "qa",
+	"southafrica": "za", "nigeria": "ng", "egypt": "eg", "kenya": "ke",
+	"brazil": "br", "mexico": "mx", "argentina": "ar", "colombia": "co",
+	"ukraine": "ua", "russia": "ru", "russianfederation": "ru", "turkey": "tr",
+}
+
+// countryPrimaryTimezones gives the most populous IANA zone for the ISO codes
+// in countryNameCodes, used when a location carries no timezone of its own.
+var countryPrimaryTimezones = map[string]string{
+	"us": "America/New_York", "gb": "Europe/London", "ca": "America/Toronto",
+	"au": "Australia/Sydney", "nz": "Pacific/Auckland", "de": "Europe/Berlin",
+	"fr": "Europe/Paris", "nl": "Europe/Amsterdam", "es": "Europe/Madrid",
+	"it": "Europe/Rome", "ie": "Europe/Dublin", "se": "Europe/Stockholm",
+	"ch": "Europe/Zurich", "no": "Europe/Oslo", "dk": "Europe/Copenhagen",
+	"fi": "Europe/Helsinki", "be": "Europe/Brussels", "at": "Europe/Vienna",
+	"pl": "Europe/Warsaw", "pt": "Europe/Lisbon", "il": "Asia/Jerusalem",
+	"in": "Asia/Kolkata", "pk": "Asia/Karachi", "bd": "Asia/Dhaka",
+	"cn": "Asia/Shanghai", "hk": "Asia/Hong_Kong", "jp": "Asia/Tokyo",
+	"kr": "Asia/Seoul", "sg": "Asia/Singapore", "ph": "Asia/Manila",
+	"id": "Asia/Jakarta", "vn": "Asia/Ho_Chi_Minh", "my": "Asia/Kuala_Lumpur",
+	"ae": "Asia/Dubai", "sa": "Asia/Riyadh", "qa": "Asia/Qatar",
+	"za": "Africa/Johannesburg", "ng": "Africa/Lagos", "eg": "Africa/Cairo",
+	"ke": "Africa/Nairobi", "br": "America/Sao_Paulo", "mx": "America/Mexico_City",
+	"ar": "America/Argentina/Buenos_Aires", "co": "America/Bogota",
+	"ua": "Europe/Kyiv", "ru": "Europe/Moscow", "tr": "Europe/Istanbul",
+}
+
+// countryGroupSets are economic/political blocs accepted as location filters.
+// Keys are ISO codes and normalizeToken'd English names.
+var countryGroupSets = map[string]map[string]struct{}{
+	"eu":    euCountrySet,
+	"gcc":   gccCountrySet,
+	"asean": aseanCountrySet,
+	"latam": latamCountrySet,
+}
+
+// countryInSet reports whether any of the job's countries (lower-cased codes
+// or names) belongs to set.
+func countryInSet(countries []string, set map[string]struct{}) bool {
+	for _, country := range countries {
+		if _, ok := set[country]; ok {
+			return true
+		}
+		if _, ok := set[normalizeToken(country)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+var euCountrySet = map[string]struct{}{
+	"at": {}, "austria": {},
+	"be": {}, "belgium": {},
+	"bg": {}, "bulgaria": {},
+	"hr": {}, "croatia": {},
+	"cy": {}, "cyprus": {},
+	"cz": {}, "czechia": {}, "czechrepublic": {},
+	"dk": {}, "denmark": {},
+	"ee": {}, "estonia": {},
+	"fi": {}, "finland": {},
+	"fr": {}, "france": {},
+	"de": {}, "germany": {},
+	"gr": {}, "greece": {},
+	"hu": {}, "hungary": {},
+	"ie": {}, "ireland": {},
+	"it": {}, "italy": {},
+	"lv": {}, "latvia": {},
+	"lt": {}, "lithuania": {},
+	"lu": {}, "luxembourg": {},
+	"mt": {}, "malta": {},
+	"nl": {}, "netherlands": {},
+	"pl": {}, "poland": {},
+	"pt": {}, "portugal": {},
+	"ro": {}, "romania": {},
+	"sk": {}, "slovakia": {},
+	"si": {}, "slovenia": {},
+	"es": {}, "spain": {},
+	"se": {}, "sweden": {},
+}
+
+var gccCountrySet = map[string]struct{}{
+	"sa": {}, "saudiarabia": {},
+	"ae": {}, "unitedarabemirates": {},
+	"qa": {}, "qatar": {},
+	"kw": {}, "kuwait": {},
+	"bh": {}, "bahrain": {},
+	"om": {}, "oman": {},
+}
+
+var aseanCountrySet = map[string]struct{}{
+	"bn": {}, "brunei": {}, "bruneidarussalam": {},
+	"kh": {}, "cambodia": {},
+	"id": {}, "indonesia": {},
+	"la": {}, "laos": {},
+	"mm": {}, "myanmar": {},
+	"my": {}, "malaysia": {},
+	"ph": {}, "philippines": {},
+	"sg": {}, "singapore": {},
+	"th": {}, "thailand": {},
+	"vn": {}, "vietnam": {},
+}
+
+var latamCountrySet = map[string]struct{}{
+	"mx": {}, "mexico": {},
+	"gt": {}, "guatemala": {},
+	"hn": {}, "honduras": {},
+	"sv": {}, "elsalvador": {},
+	"ni": {}, "nicaragua": {},
+	"cr": {}, "costarica": {},
+	"pa": {}, "panama": {},
+	"cu": {}, "cuba": {},
+	"do": {}, "dominicanrepublic": {},
+	"pr": {}, "puertorico": {},
+	"co": {}, "colombia": {},
+	"ve": {}, "venezuela": {},
+	"ec": {}, "ecuador": {},
+	"pe": {}, "peru": {},
+	"bo": {}, "bolivia": {},
+	"br": {}, "brazil": {},
+	"py": {}, "paraguay": {},
+	"uy": {}, "uruguay": {},
+	"ar": {}, "argentina": {},
+	"cl": {}, "chile": {},
+}
+
 var caribbeanCountrySet = map[string]struct{}{
 	"ag": {}, "antiguaandbarbuda": {},
 	"ai": {}, "anguilla": {},
@@ -977,84 +1571,171 @@ func sortJobs(jobs []JobRecord, opts FilterOptions) {
 		return
 	}
 
+	keys := opts.sortKeys()
+	if opts.hasSortField(SortHot) {
+		scoreHotJobs(jobs, opts.SearchExpression, time.Now().UTC())
+	}
+
 	sort.SliceStable(jobs, func(i, j int) bool {
 		a := jobs[i]
 		b := jobs[j]
 
-		switch opts.SortField {
-		case SortPublishTime:
-			aTime := timeOrZero(a.PublishTime)
-			bTime := timeOrZero(b.PublishTime)
-
-			// Handle nil/zero times - always sort them to the end regardless of direction
-			aZero := aTime.IsZero()
-			bZero := bTime.IsZero()
-
-			if aZero && bZero {
-				return compareFallback(a, b, opts.SortAscending)
-			}
-			if aZero {
-				return false // a goes to the end
-			}
-			if bZero {
-				return true // b goes to the end, a comes first
-			}
-
-			if aTime.Equal(bTime) {
-				return compareFallback(a, b, opts.SortAscending)
+		for _, key := range keys {
+			if cmp := compareByKey(a, b, key); cmp != 0 {
+				return cmp < 0
 			}
+		}
+		return compareFallback(a, b, keys[0].Ascending)
+	})
+}
 
-			if opts.SortAscending {
-				return aTime.Before(bTime)
-			}
-			return aTime.After(bTime)
-		case SortBudget:
-			aValue, aOK := budgetMetric(a)
-			bValue, bOK := budgetMetric(b)
-			if !aOK && !bOK {
-				return compareFallback(a, b, opts.SortAscending)
-			}
-			if !aOK {
-				return false
-			}
-			if !bOK {
-				return true
-			}
-			if aValue == bValue {
-				return compareFallback(a, b, opts.SortAscending)
-			}
-			if opts.SortAscending {
-				return aValue < bValue
-			}
-			return aValue > bValue
+// compareByKey orders two jobs by a single sort key, returning -1 when a
+// sorts first, 1 when b does and 0 on a tie. Jobs missing the value always
+// sort to the end regardless of direction.
+func compareByKey(a, b JobRecord, key sortKey) int {
+	switch key.Field {
+	case SortPublishTime:
+		return compareTimes(a.PublishTime, b.PublishTime, key.Ascending)
+	case SortCreatedOn:
+		return compareTimes(a.CreatedOn, b.CreatedOn, key.Ascending)
+	case SortHot:
+		switch {
+		case a.HotScore == b.HotScore:
+			return 0
+		case a.HotScore > b.HotScore:
+			return -1
 		default:
-			aTime := timeOrZero(a.LastVisitedAt)
-			bTime := timeOrZero(b.LastVisitedAt)
+			return 1
+		}
+	case SortQuality:
+		return compareOptionalFloats(a.QualityScore, b.QualityScore, key.Ascending)
+	case SortFreshness:
+		return compareTimes(freshnessTime(a), freshnessTime(b), key.Ascending)
+	case SortBudget:
+		aValue, aOK := budgetMetric(a)
+		bValue, bOK := budgetMetric(b)
+		switch {
+		case !aOK && !bOK:
+			return 0
+		case !aOK:
+			return 1
+		case !bOK:
+			return -1
+		case aValue == bValue:
+			return 0
+		case (aValue < bValue) == key.Ascending:
+			return -1
+		default:
+			return 1
+		}
+	default:
+		return compareTimes(a.LastVisitedAt, b.LastVisitedAt, key.Ascending)
+	}
+}
 
-			// Handle nil/zero times - always sort them to the end regardless of direction
-			aZero := aTime.IsZero()
-			bZero := bTime.IsZero()
+// freshnessTime is the later of the job's publish time and last visit, so a
+// job confirmed open recently ranks as fresh even if it was posted long ago.
+// It is nil only when both are missing.
+func freshnessTime(job JobRecord) *time.Time {
+	switch {
+	case job.PublishTime == nil:
+		return job.LastVisitedAt
+	case job.LastVisitedAt == nil:
+		return job.PublishTime
+	case job.LastVisitedAt.After(*job.PublishTime):
+		return job.LastVisitedAt
+	default:
+		return job.PublishTime
+	}
+}
 
-			if aZero && bZero {
-				return compareFallback(a, b, opts.SortAscending)
-			}
-			if aZero {
-				return false // a goes to the end
-			}
-			if bZero {
-				return true // b goes to the end, a comes first
-			}
+// compareOptionalFloats compares two values in the requested direction with
+// nil values placed last.
+func compareOptionalFloats(a, b *float64, ascending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case *a == *b:
+		return 0
+	case (*a < *b) == ascending:
+		return -1
+	default:
+		return 1
+	}
+}
 
-			if aTime.Equal(bTime) {
-				return compareFallback(a, b, opts.SortAscending)
-			}
+// hotHalfLife is the age at which a job's hot score halves.
+// Override with HOT_SORT_HALF_LIFE via ConfigureHotHalfLife.
+var hotHalfLife = 24 * time.Hour
 
-			if opts.SortAscending {
-				return aTime.Before(bTime)
-			}
-			return aTime.After(bTime)
+// ConfigureHotHalfLife sets the sort=hot half-life from a duration such as "12h".
+func ConfigureHotHalfLife(raw string) error {
+	halfLife, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	if halfLife <= 0 {
+		return fmt.Errorf("half-life must be positive, got %v", halfLife)
+	}
+	hotHalfLife = halfLife
+	return nil
+}
+
+// scoreHotJobs sets HotScore on every job for sort=hot:
+//
+//	hot = relevance * exp(-ln2 * age / halfLife)
+//
+// i.e. the score halves every hotHalfLife. relevance comes from the search
+// expression (1 when there is none) and age from the publish time, falling
+// back to the creation time. Jobs with no timestamp score 0.
+func scoreHotJobs(jobs []JobRecord, expr *SearchExpression, now time.Time) {
+	for i := range jobs {
+		job := &jobs[i]
+		published := job.PublishTime
+		if published == nil || published.IsZero() {
+			published = job.CreatedOn
+		}
+		if published == nil || published.IsZero() {
+			job.HotScore = 0
+			continue
 		}
-	})
+
+		age := now.Sub(*published)
+		if age < 0 {
+			age = 0
+		}
+		decay := math.Exp(-math.Ln2 * float64(age) / float64(hotHalfLife))
+		job.HotScore = expr.Relevance(job) * decay
+	}
+}
+
+// compareTimes compares two timestamps in the requested direction with
+// nil/zero times placed last.
+func compareTimes(aPtr, bPtr *time.Time, ascending bool) int {
+	aTime := timeOrZero(aPtr)
+	bTime := timeOrZero(bPtr)
+
+	aZero := aTime.IsZero()
+	bZero := bTime.IsZero()
+
+	switch {
+	case aZero && bZero:
+		return 0
+	case aZero:
+		return 1 // a goes to the end
+	case bZero:
+		return -1 // b goes to the end, a comes first
+	case aTime.Equal(bTime):
+		return 0
+	case aTime.Before(bTime) == ascending:
+		return -1
+	default:
+		return 1
+	}
 }
 
 func compareFallback(a JobRecord, b JobRecord, ascending bool) bool {
@@ -0,0 +1,898 @@
+package server
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleJobPayload(uid, title string) map[string]interface{} {
+	return map[string]interface{}{
+		"uid":            uid,
+		"title":          title,
+		"description":    title + " description",
+		"type":           int64(2),
+		"contractorTier": int64(3),
+		"ciphertext":     "~01" + uid,
+		"publishTime":    "2025-01-10T12:00:00Z",
+		"budget":         map[string]interface{}{"amount": 750.0, "currencyCode": "USD"},
+		"ontologySkills": []interface{}{
+			map[string]interface{}{"prefLabel": "Python"},
+			map[string]interface{}{"prefLabel": "Web Scraping"},
+		},
+	}
+}
+
+func TestTransformDocumentDataCandidatePaths(t *testing.T) {
+	lastVisited := map[string]interface{}{"last_visited_at": "2025-01-11T08:30:00Z"}
+
+	tests := []struct {
+		name      string
+		doc       map[string]interface{}
+		wantID    string
+		wantTitle string
+		wantURL   string
+	}{
+		{
+			name: "state.jobDetails.job",
+			doc: map[string]interface{}{
+				"url":             "https://www.upwork.com/jobs/~01details",
+				"scrape_metadata": lastVisited,
+				"state": map[string]interface{}{
+					"jobDetails": map[string]interface{}{"job": sampleJobPayload("details", "Details path")},
+				},
+			},
+			wantID:    "details",
+			wantTitle: "Details path",
+			wantURL:   "https://www.upwork.com/jobs/~01details",
+		},
+		{
+			name: "state.job.job",
+			doc: map[string]interface{}{
+				"scrape_metadata": lastVisited,
+				"state": map[string]interface{}{
+					"job": map[string]interface{}{"job": sampleJobPayload("nested", "Nested path")},
+				},
+			},
+			wantID:    "nested",
+			wantTitle: "Nested path",
+			wantURL:   "https://www.upwork.com/jobs/~01nested",
+		},
+		{
+			name: "state.job",
+			doc: map[string]interface{}{
+				"scrape_metadata": lastVisited,
+				"state": map[string]interface{}{
+					"job": sampleJobPayload("state-job", "State job path"),
+				},
+			},
+			wantID:    "state-job",
+			wantTitle: "State job path",
+			wantURL:   "https://www.upwork.com/jobs/~01state-job",
+		},
+		{
+			name: "raw.job",
+			doc: map[string]interface{}{
+				"scrape_metadata": lastVisited,
+				"state":           map[string]interface{}{"other": true},
+				"job":             sampleJobPayload("raw-job", "Raw job path"),
+			},
+			wantID:    "raw-job",
+			wantTitle: "Raw job path",
+			wantURL:   "https://www.upwork.com/jobs/~01raw-job",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := transformDocumentData(tc.doc, "doc-id")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			rec := records[0]
+
+			if rec.ID != tc.wantID {
+				t.Fatalf("expected ID %q, got %q", tc.wantID, rec.ID)
+			}
+			if rec.Title != tc.wantTitle {
+				t.Fatalf("expected title %q, got %q", tc.wantTitle, rec.Title)
+			}
+			if rec.URL != tc.wantURL {
+				t.Fatalf("expected URL %q, got %q", tc.wantURL, rec.URL)
+			}
+			if rec.JobType == nil || *rec.JobType != 2 {
+				t.Fatalf("unexpected job type: %+v", rec.JobType)
+			}
+			if rec.ContractorTier == nil || *rec.ContractorTier != 3 {
+				t.Fatalf("unexpected contractor tier: %+v", rec.ContractorTier)
+			}
+			if rec.Budget == nil || rec.Budget.FixedAmount == nil || *rec.Budget.FixedAmount != 750 || rec.Budget.Currency != "USD" {
+				t.Fatalf("unexpected budget: %+v", rec.Budget)
+			}
+			if !reflect.DeepEqual(rec.Skills, []string{"Python", "Web Scraping"}) {
+				t.Fatalf("unexpected skills: %+v", rec.Skills)
+			}
+			wantPublish := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+			if rec.PublishTime == nil || !rec.PublishTime.Equal(wantPublish) {
+				t.Fatalf("unexpected publish time: %+v", rec.PublishTime)
+			}
+			wantVisited := time.Date(2025, 1, 11, 8, 30, 0, 0, time.UTC)
+			if rec.LastVisitedAt == nil || !rec.LastVisitedAt.Equal(wantVisited) {
+				t.Fatalf("unexpected last visited: %+v", rec.LastVisitedAt)
+			}
+			if rec.IsPrivate {
+				t.Fatalf("expected public job")
+			}
+		})
+	}
+}
+
+func TestTransformDocumentDataPrivatePlaceholder(t *testing.T) {
+	doc := map[string]interface{}{
+		"url":             "https://www.upwork.com/jobs/~01private",
+		"scrape_metadata": map[string]interface{}{"last_visited_at": "2025-01-11T08:30:00Z"},
+		"state": map[string]interface{}{
+			"job": map[string]interface{}{
+				"errorResponse": map[string]interface{}{"status": int64(403), "text": "{}"},
+			},
+		},
+	}
+
+	records, err := transformDocumentData(doc, "private-doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.ID != "private-doc" || !rec.IsPrivate {
+		t.Fatalf("expected private placeholder for private-doc, got %+v", rec)
+	}
+	if !strings.Contains(rec.PrivacyReason, "403") {
+		t.Fatalf("unexpected privacy reason: %q", rec.PrivacyReason)
+	}
+	if rec.URL != "https://www.upwork.com/jobs/~01private" || rec.LastVisitedAt == nil {
+		t.Fatalf("expected URL and last visited to carry over, got %+v", rec)
+	}
+}
+
+func TestTransformDocumentDataSimilarJobsFallback(t *testing.T) {
+	doc := map[string]interface{}{
+		"state": map[string]interface{}{
+			"job": map[string]interface{}{
+				"errorResponse": map[string]interface{}{
+					"status": int64(403),
+					"text":   "Private job",
+					"similarJobs": []interface{}{
+						sampleJobPayload("similar-1", "First similar"),
+						sampleJobPayload("similar-2", "Second similar"),
+						sampleJobPayload("similar-1", "Duplicate similar"),
+					},
+				},
+			},
+		},
+	}
+
+	records, err := transformDocumentData(doc, "private-doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := jobIDs(records); !reflect.DeepEqual(got, []string{"similar-1", "similar-2"}) {
+		t.Fatalf("unexpected similar job IDs: %v", got)
+	}
+	for _, rec := range records {
+		if !rec.IsPrivate || rec.PrivacyReason != "Private job" {
+			t.Fatalf("expected similar jobs to inherit privacy flags, got %+v", rec)
+		}
+		if !rec.FromSimilar {
+			t.Fatalf("expected similar jobs to be flagged from_similar, got %+v", rec)
+		}
+	}
+}
+
+func TestSimilarJobRecordsForPrivatePrimary(t *testing.T) {
+	doc := map[string]interface{}{
+		"state": map[string]interface{}{
+			"jobDetails": map[string]interface{}{
+				"job": sampleJobPayload("private-primary", "Restricted job"),
+			},
+			"job": map[string]interface{}{
+				"errorResponse": map[string]interface{}{
+					"status": int64(403),
+					"similarJobs": []interface{}{
+						sampleJobPayload("similar-1", "First similar"),
+						sampleJobPayload("similar-2", "Second similar"),
+					},
+				},
+			},
+		},
+	}
+
+	records, err := transformDocumentData(doc, "private-doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := jobIDs(records); !reflect.DeepEqual(got, []string{"private-primary"}) || !hasPrivateRecord(records) {
+		t.Fatalf("expected only the private primary job, got %+v", records)
+	}
+
+	similar := similarJobRecords(doc, "private-doc")
+	if got := jobIDs(similar); !reflect.DeepEqual(got, []string{"similar-1", "similar-2"}) {
+		t.Fatalf("unexpected similar job IDs: %v", got)
+	}
+	for _, rec := range similar {
+		if !rec.FromSimilar || rec.IsPrivate {
+			t.Fatalf("expected public similar job flagged from_similar, got %+v", rec)
+		}
+		if !rec.ToDTO().FromSimilar {
+			t.Fatalf("expected from_similar in DTO")
+		}
+	}
+}
+
+func TestTransformDocumentDataBuyerStats(t *testing.T) {
+	doc := map[string]interface{}{
+		"state": map[string]interface{}{
+			"jobDetails": map[string]interface{}{
+				"job": sampleJobPayload("buyer-job", "Buyer stats"),
+				"buyer": map[string]interface{}{
+					"isPaymentMethodVerified": true,
+					"location": map[string]interface{}{
+						"country":         "us",
+						"city":            " Austin ",
+						"countryTimezone": "America/Chicago",
+					},
+					"stats": map[string]interface{}{
+						"totalCharges":           map[string]interface{}{"amount": 12500.5},
+						"totalAssignments":       int64(14),
+						"totalJobsWithHires":     int64(9),
+						"activeAssignmentsCount": int64(2),
+						"feedbackCount":          int64(11),
+						"hoursCount":             320.5,
+						"score":                  4.8,
+					},
+					"company": map[string]interface{}{
+						"industry":     "Tech & IT",
+						"size":         int64(25),
+						"contractDate": "2019-05-01T00:00:00Z",
+					},
+					"jobs": map[string]interface{}{"openCount": int64(3)},
+				},
+			},
+		},
+	}
+
+	records, err := transformDocumentData(doc, "doc-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buyer := records[0].Buyer
+	if buyer == nil {
+		t.Fatalf("expected buyer info")
+	}
+	if buyer.PaymentVerified == nil || !*buyer.PaymentVerified {
+		t.Fatalf("expected payment verified buyer")
+	}
+	if buyer.Country != "US" || buyer.City != "Austin" || buyer.Timezone != "America/Chicago" {
+		t.Fatalf("unexpected buyer location: %+v", buyer)
+	}
+	if buyer.TotalSpent == nil || *buyer.TotalSpent != 12500.5 {
+		t.Fatalf("unexpected total spent: %+v", buyer.TotalSpent)
+	}
+	if buyer.TotalAssignments == nil || *buyer.TotalAssignments != 14 ||
+		buyer.TotalJobsWithHires == nil || *buyer.TotalJobsWithHires != 9 ||
+		buyer.ActiveAssignments == nil || *buyer.ActiveAssignments != 2 ||
+		buyer.FeedbackCount == nil || *buyer.FeedbackCount != 11 {
+		t.Fatalf("unexpected buyer counters: %+v", buyer)
+	}
+	if buyer.TotalHours == nil || *buyer.TotalHours != 320.5 || buyer.Score == nil || *buyer.Score != 4.8 {
+		t.Fatalf("unexpected buyer hours/score: %+v", buyer)
+	}
+	if buyer.CompanyIndustry != "Tech & IT" || buyer.CompanySize == nil || *buyer.CompanySize != 25 {
+		t.Fatalf("unexpected company info: %+v", buyer)
+	}
+	if buyer.ContractDate == nil || buyer.ContractDate.Year() != 2019 {
+		t.Fatalf("unexpected contract date: %+v", buyer.ContractDate)
+	}
+	if buyer.OpenJobsCount == nil || *buyer.OpenJobsCount != 3 {
+		t.Fatalf("unexpected open jobs count: %+v", buyer.OpenJobsCount)
+	}
+}
+
+func TestTransformDocumentDataRejectsMissingState(t *testing.T) {
+	if _, err := transformDocumentData(map[string]interface{}{"url": "x"}, "doc-id"); err == nil {
+		t.Fatalf("expected error for document without state")
+	}
+}
+
+func TestDetectPrivacyStatusCodes(t *testing.T) {
+	jobState := func(code int64) map[string]interface{} {
+		return map[string]interface{}{
+			"errorResponse": map[string]interface{}{"status": code, "text": "{\"error\":true}"},
+		}
+	}
+
+	tests := []struct {
+		code        int64
+		wantPrivate bool
+		wantStatus  string
+		wantReason  string
+	}{
+		{code: 401, wantPrivate: true, wantStatus: "requires_login", wantReason: "This job requires login to view (401)."},
+		{code: 403, wantPrivate: true, wantStatus: "private", wantReason: "This job is private or restricted (403)."},
+		{code: 404, wantPrivate: true, wantStatus: "removed", wantReason: "This job has been removed (404)."},
+		{code: 500, wantPrivate: false},
+	}
+
+	for _, tc := range tests {
+		isPrivate, status, reason := detectPrivacy(jobState(tc.code))
+		if isPrivate != tc.wantPrivate || status != tc.wantStatus || reason != tc.wantReason {
+			t.Fatalf("status %d: got (%v, %q, %q), want (%v, %q, %q)", tc.code, isPrivate, status, reason, tc.wantPrivate, tc.wantStatus, tc.wantReason)
+		}
+	}
+}
+
+func TestConfigurePrivacyStatusCodes(t *testing.T) {
+	original := privacyStatusCodes
+	t.Cleanup(func() { privacyStatusCodes = original })
+
+	if err := ConfigurePrivacyStatusCodes("403, 410"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if isPrivate, _, _ := detectPrivacy(map[string]interface{}{"errorResponse": map[string]interface{}{"status": int64(404)}}); isPrivate {
+		t.Fatalf("expected 404 to be ignored once removed from the configured set")
+	}
+	isPrivate, status, reason := detectPrivacy(map[string]interface{}{"errorResponse": map[string]interface{}{"status": int64(410)}})
+	if !isPrivate || status != "unavailable" || reason != "This job is unavailable (410)." {
+		t.Fatalf("unexpected result for custom code: (%v, %q, %q)", isPrivate, status, reason)
+	}
+
+	if err := ConfigurePrivacyStatusCodes("abc"); err == nil {
+		t.Fatalf("expected error for non-numeric status code")
+	}
+}
+
+// projectDocument mimics Firestore's Select by keeping only the given dotted paths.
+func projectDocument(doc map[string]interface{}, paths []string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		value, ok := dig(doc, keys...)
+		if !ok {
+			continue
+		}
+		dst := projected
+		for _, key := range keys[:len(keys)-1] {
+			next, ok := dst[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				dst[key] = next
+			}
+			dst = next
+		}
+		dst[keys[len(keys)-1]] = value
+	}
+	return projected
+}
+
+func TestTransformDocumentDataWithProjection(t *testing.T) {
+	doc := map[string]interface{}{
+		"url":             "https://www.upwork.com/jobs/~01projected",
+		"publishTime":     "2025-01-10T12:00:00Z",
+		"scrape_metadata": map[string]interface{}{"last_visited_at": "2025-01-11T08:30:00Z"},
+		"state": map[string]interface{}{
+			"jobDetails": map[string]interface{}{
+				"job": sampleJobPayload("projected", "Projected job"),
+				"buyer": map[string]interface{}{
+					"isPaymentMethodVerified": true,
+					"location":                map[string]interface{}{"country": "US"},
+				},
+				"applicants": []interface{}{"not needed"},
+			},
+		},
+		"search_context": map[string]interface{}{"query": "not needed"},
+	}
+
+	full, err := transformDocumentData(doc, "projected")
+	if err != nil {
+		t.Fatalf("unexpected error on full document: %v", err)
+	}
+	projected, err := transformDocumentData(projectDocument(doc, jobProjectionPaths), "projected")
+	if err != nil {
+		t.Fatalf("unexpected error on projected document: %v", err)
+	}
+	if !reflect.DeepEqual(full, projected) {
+		t.Fatalf("projection changed transform output:\nfull:      %+v\nprojected: %+v", full, projected)
+	}
+}
+
+func TestTransformDocumentDataLastVisitedFallbacks(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"url":   "https://www.upwork.com/jobs/~01visited",
+			"state": map[string]interface{}{"jobDetails": map[string]interface{}{"job": sampleJobPayload("visited", "Visited job")}},
+		}
+	}
+	want := time.Date(2025, 1, 11, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		set  func(doc map[string]interface{})
+	}{
+		{"scrape_metadata.last_scraped", func(doc map[string]interface{}) {
+			doc["scrape_metadata"] = map[string]interface{}{"last_scraped": "2025-01-11T08:30:00Z"}
+		}},
+		{"root updated_at", func(doc map[string]interface{}) { doc["updated_at"] = want }},
+		{"last_visited_at preferred", func(doc map[string]interface{}) {
+			doc["scrape_metadata"] = map[string]interface{}{"last_visited_at": "2025-01-11T08:30:00Z", "updated_at": "2024-06-01T00:00:00Z"}
+			doc["last_scraped"] = "2024-06-01T00:00:00Z"
+		}},
+	}
+	for _, tt := range tests {
+		doc := base()
+		tt.set(doc)
+		for _, input := range []map[string]interface{}{doc, projectDocument(doc, jobProjectionPaths)} {
+			records, err := transformDocumentData(input, "visited")
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			if got := records[0].LastVisitedAt; got == nil || !got.Equal(want) {
+				t.Fatalf("%s: expected last visit %v, got %v", tt.name, want, got)
+			}
+		}
+	}
+}
+
+func TestSortJobsByCreatedOn(t *testing.T) {
+	at := func(hour int) *time.Time {
+		ts := time.Date(2025, 1, 10, hour, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	jobs := func() []JobRecord {
+		return []JobRecord{
+			{ID: "mid", CreatedOn: at(10)},
+			{ID: "missing"},
+			{ID: "old", CreatedOn: at(8)},
+			{ID: "new", CreatedOn: at(12)},
+		}
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{sort: "created_on_desc", want: []string{"new", "mid", "old", "missing"}},
+		{sort: "created_on_asc", want: []string{"old", "mid", "new", "missing"}},
+	}
+
+	for _, tc := range tests {
+		opts := FilterOptions{}
+		applySortParam(&opts, tc.sort)
+		if opts.SortField != SortCreatedOn {
+			t.Fatalf("%s: expected SortCreatedOn, got %q", tc.sort, opts.SortField)
+		}
+
+		got := jobs()
+		sortJobs(got, opts)
+		if ids := jobIDs(got); !reflect.DeepEqual(ids, tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.sort, ids, tc.want)
+		}
+	}
+}
+
+func TestSortJobsByFreshness(t *testing.T) {
+	at := func(hour int) *time.Time {
+		ts := time.Date(2025, 1, 10, hour, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	jobs := func() []JobRecord {
+		return []JobRecord{
+			{ID: "new-post", PublishTime: at(11), LastVisitedAt: at(11)},
+			{ID: "reverified", PublishTime: at(2), LastVisitedAt: at(12)},
+			{ID: "missing"},
+			{ID: "visit-only", LastVisitedAt: at(9)},
+			{ID: "stale", PublishTime: at(5), LastVisitedAt: at(6)},
+		}
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{sort: "freshness_desc", want: []string{"reverified", "new-post", "visit-only", "stale", "missing"}},
+		{sort: "freshness_asc", want: []string{"stale", "visit-only", "new-post", "reverified", "missing"}},
+	}
+
+	for _, tc := range tests {
+		opts := FilterOptions{}
+		applySortParam(&opts, tc.sort)
+		if opts.SortField != SortFreshness {
+			t.Fatalf("%s: expected SortFreshness, got %q", tc.sort, opts.SortField)
+		}
+
+		got := jobs()
+		sortJobs(got, opts)
+		if ids := jobIDs(got); !reflect.DeepEqual(ids, tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.sort, ids, tc.want)
+		}
+	}
+}
+
+func TestSortJobsMultiKey(t *testing.T) {
+	day := func(d int) *time.Time {
+		ts := time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	amount := func(v float64) *BudgetInfo { return &BudgetInfo{FixedAmount: &v} }
+
+	jobs := []JobRecord{
+		{ID: "old-big", PublishTime: day(9), Budget: amount(900)},
+		{ID: "new-small", PublishTime: day(10), Budget: amount(100)},
+		{ID: "new-none", PublishTime: day(10)},
+		{ID: "new-big", PublishTime: day(10), Budget: amount(800)},
+	}
+
+	opts := FilterOptions{}
+	if err := applySortParam(&opts, "publish_time_desc, budget_desc, bogus"); err == nil || len(opts.SortKeys) != 0 {
+		t.Fatalf("expected an unknown entry to fail and keep the sort, got %v, %+v", err, opts.SortKeys)
+	}
+	if err := applySortParam(&opts, "publish_time_desc, budget_desc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantKeys := []sortKey{{Field: SortPublishTime}, {Field: SortBudget}}
+	if !reflect.DeepEqual(opts.SortKeys, wantKeys) {
+		t.Fatalf("unexpected sort keys: %+v", opts.SortKeys)
+	}
+	if opts.SortField != SortPublishTime || opts.SortAscending {
+		t.Fatalf("primary sort not applied: %+v", opts)
+	}
+
+	sortJobs(jobs, opts)
+	want := []string{"new-big", "new-small", "new-none", "old-big"}
+	if got := jobIDs(jobs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if label := formatFilterOptions(opts); !strings.Contains(label, "sort=publish_time_desc,budget_desc") {
+		t.Fatalf("unexpected formatted options: %s", label)
+	}
+}
+
+func TestSortJobsHot(t *testing.T) {
+	now := time.Now().UTC()
+	ago := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	jobs := []JobRecord{
+		{ID: "fresh-weak", Title: "Data entry", Description: "Some python glue code", PublishTime: ago(time.Minute)},
+		{ID: "undated", Title: "Python undated"},
+		{ID: "older-strong", Title: "Python scraper", PublishTime: ago(30 * time.Hour)},
+		{ID: "stale-strong", Title: "Python API", PublishTime: ago(96 * time.Hour)},
+	}
+
+	opts := FilterOptions{}
+	applySortParam(&opts, "hot")
+	if err := opts.ApplySearchQuery("python"); err != nil {
+		t.Fatalf("unexpected search error: %v", err)
+	}
+
+	// With a 24h half-life: older-strong = 3 * 0.42, fresh-weak ≈ 1, stale-strong = 3 * 0.0625.
+	sortJobs(jobs, opts)
+	want := []string{"older-strong", "fresh-weak", "stale-strong", "undated"}
+	if got := jobIDs(jobs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSearchExpressionRelevance(t *testing.T) {
+	expr, err := ParseSearchQuery("python OR golang NOT php")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		job  JobRecord
+		want float64
+	}{
+		{name: "title match", job: JobRecord{Title: "Python developer"}, want: 3},
+		{name: "skill match", job: JobRecord{Title: "Backend", Skills: []string{"Golang"}}, want: 2},
+		{name: "description match", job: JobRecord{Title: "Backend", Description: "python and golang"}, want: 2},
+		{name: "negated term ignored", job: JobRecord{Title: "PHP"}, want: 0},
+	}
+
+	for _, tc := range tests {
+		if got := expr.Relevance(&tc.job); got != tc.want {
+			t.Fatalf("%s: Relevance() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	var none *SearchExpression
+	if got := none.Relevance(&JobRecord{}); got != 1 {
+		t.Fatalf("nil expression relevance = %v, want 1", got)
+	}
+}
+
+func TestSearchFieldWeights(t *testing.T) {
+	defaults := searchFieldWeights
+	defer func() { searchFieldWeights = defaults }()
+
+	if err := ConfigureSearchFieldWeights("skills=5, description=0.5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if searchFieldWeights["title"] != 3 || searchFieldWeights["skills"] != 5 {
+		t.Fatalf("unexpected weights: %v", searchFieldWeights)
+	}
+
+	expr, err := ParseSearchQuery("python")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		job  JobRecord
+		want float64
+	}{
+		{job: JobRecord{Title: "Python developer", Skills: []string{"Python"}}, want: 5},
+		{job: JobRecord{Title: "Python developer"}, want: 3},
+		{job: JobRecord{Title: "Backend", Description: "python"}, want: 0.5},
+	}
+	for _, tc := range tests {
+		if got := expr.Relevance(&tc.job); got != tc.want {
+			t.Fatalf("Relevance(%q) = %v, want %v", tc.job.Title, got, tc.want)
+		}
+	}
+
+	for _, raw := range []string{"body=2", "title", "title=-1"} {
+		if err := ConfigureSearchFieldWeights(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestToDTOWithLocation(t *testing.T) {
+	published := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	job := JobRecord{ID: "a", PublishTime: &published, LastVisitedAt: &published}
+
+	if dto := job.ToDTO(); dto.PublishTime != "2025-01-10T12:00:00Z" {
+		t.Fatalf("expected UTC by default, got %q", dto.PublishTime)
+	}
+
+	dto := job.ToDTOWith(DTOOptions{Location: parseOutputLocation("America/New_York")})
+	if dto.PublishTime != "2025-01-10T07:00:00-05:00" || dto.LastVisitedAt != "2025-01-10T07:00:00-05:00" {
+		t.Fatalf("unexpected localized timestamps: %q, %q", dto.PublishTime, dto.LastVisitedAt)
+	}
+
+	for _, raw := range []string{"Mars/Olympus_Mons", "Local"} {
+		if loc := parseOutputLocation(raw); loc != time.UTC {
+			t.Fatalf("expected UTC fallback for %q, got %v", raw, loc)
+		}
+	}
+}
+
+func TestSearchMatchesBuyerCompany(t *testing.T) {
+	job := JobRecord{
+		Title: "Landing page",
+		Buyer: &BuyerInfo{CompanyName: "Acme Robotics", CompanyIndustry: "Health & Fitness"},
+	}
+	idx := buildSearchDocumentIndex(&job)
+
+	for _, query := range []string{"fitness", "acme", "\"acme robotics\""} {
+		expr, err := ParseSearchQuery(query)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", query, err)
+		}
+		if !expr.Evaluate(idx) {
+			t.Fatalf("expected %q to match buyer company fields", query)
+		}
+	}
+}
+
+func TestTransformJobListData(t *testing.T) {
+	doc := sampleJobPayload("list-1", "Listed job")
+	doc["scrape_metadata"] = map[string]interface{}{"last_visited_at": "2025-01-10T12:00:00Z"}
+
+	records, err := transformJobListData(doc, "doc-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "list-1" || records[0].Title != "Listed job" {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	if records[0].LastVisitedAt == nil {
+		t.Fatalf("expected last_visited_at from scrape_metadata")
+	}
+
+	if _, err := transformJobListData(nil, "doc-id"); err == nil {
+		t.Fatalf("expected error for empty document")
+	}
+}
+
+func TestTimezoneExtractionIsConsistent(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  map[string]interface{}
+		want string
+	}{
+		{"city timezone wins", map[string]interface{}{"country": "United States", "timezone": "America/Denver", "countryTimezone": "America/Chicago"}, "America/Denver"},
+		{"country timezone next", map[string]interface{}{"country": "US", "countryTimezone": "America/Chicago"}, "America/Chicago"},
+		{"derived from country name", map[string]interface{}{"country": "India"}, "Asia/Kolkata"},
+		{"derived from country code", map[string]interface{}{"country": "de"}, "Europe/Berlin"},
+		{"unknown country", map[string]interface{}{"country": "Atlantis"}, ""},
+	}
+	for _, tt := range tests {
+		buyer := buildBuyer(map[string]interface{}{"location": tt.loc})
+		location := buildJobLocation(map[string]interface{}{"location": tt.loc})
+		if buyer == nil || location == nil {
+			t.Fatalf("%s: expected buyer and location, got %+v, %+v", tt.name, buyer, location)
+		}
+		if buyer.Timezone != tt.want || location.Timezone != tt.want {
+			t.Fatalf("%s: buyer=%q location=%q, want %q", tt.name, buyer.Timezone, location.Timezone, tt.want)
+		}
+	}
+
+	// Derived zones make timezone filters match jobs that only carry a country
+	opts := FilterOptions{Timezones: []string{"asia/kolkata"}}
+	job := &JobRecord{Buyer: buildBuyer(map[string]interface{}{"location": map[string]interface{}{"country": "India"}})}
+	if !applyFilters(job, opts) {
+		t.Fatalf("expected derived timezone to satisfy the timezone filter")
+	}
+}
+
+func TestBuildBudgetCurrencyConflict(t *testing.T) {
+	original := budgetCurrencySource
+	t.Cleanup(func() { budgetCurrencySource = original })
+
+	job := map[string]interface{}{
+		"budget": map[string]interface{}{"amount": 500.0, "currencyCode": "USD"},
+		"amount": map[string]interface{}{"amount": 450.0, "currencyCode": "EUR"},
+	}
+
+	budget, hourly := buildBudget(job)
+	if budget == nil || *budget.FixedAmount != 450 || budget.Currency != "EUR" {
+		t.Fatalf("expected the amount map to win by default, got %+v", budget)
+	}
+	if sec := budget.Secondary; sec == nil || *sec.FixedAmount != 500 || sec.Currency != "USD" || sec.Source != BudgetSourceBudget {
+		t.Fatalf("expected the budget map as secondary, got %+v", budget.Secondary)
+	}
+	if hourly == nil || hourly.Currency != "EUR" {
+		t.Fatalf("expected hourly currency to follow the primary budget, got %+v", hourly)
+	}
+
+	if err := ConfigureBudgetCurrencySource("Budget"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	budget, _ = buildBudget(job)
+	if *budget.FixedAmount != 500 || budget.Currency != "USD" || budget.Secondary.Source != BudgetSourceAmount || budget.Secondary.Currency != "EUR" {
+		t.Fatalf("expected the budget map to win when configured, got %+v", budget)
+	}
+
+	// Matching currencies still merge without a secondary entry
+	job["amount"] = map[string]interface{}{"amount": 450.0, "currencyCode": "usd"}
+	if budget, _ = buildBudget(job); budget.Secondary != nil || *budget.FixedAmount != 450 {
+		t.Fatalf("expected a merged budget without secondary, got %+v", budget)
+	}
+
+	if err := ConfigureBudgetCurrencySource("both"); err == nil {
+		t.Fatalf("expected error for unknown source")
+	}
+}
+
+func TestTransformDocumentDataRecnoPrecision(t *testing.T) {
+	const want int64 = 1<<53 + 1 // not representable as float64
+
+	for _, raw := range []interface{}{want, json.Number("9007199254740993"), "9007199254740993"} {
+		job := sampleJobPayload("recno", "Recno job")
+		job["recno"] = raw
+		doc := map[string]interface{}{"state": map[string]interface{}{"jobDetails": map[string]interface{}{"job": job}}}
+
+		records, err := transformDocumentData(doc, "recno")
+		if err != nil {
+			t.Fatalf("%T: unexpected error: %v", raw, err)
+		}
+		if got := records[0].Recno; got == nil || *got != want {
+			t.Fatalf("%T: expected recno %d, got %v", raw, want, got)
+		}
+	}
+}
+
+func TestCheckFuturePublishTime(t *testing.T) {
+	originalWindow, originalAction := futurePublishWindow, futurePublishAction
+	t.Cleanup(func() { futurePublishWindow, futurePublishAction = originalWindow, originalAction })
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	skewed := now.Add(30 * time.Minute)
+	future := now.Add(72 * time.Hour)
+
+	if got := checkFuturePublishTime("job", &skewed, now); got == nil || !got.Equal(skewed) {
+		t.Fatalf("expected clock skew inside the window to be kept, got %v", got)
+	}
+	if got := checkFuturePublishTime("job", &future, now); got == nil || !got.Equal(future) {
+		t.Fatalf("expected future time to be kept by default, got %v", got)
+	}
+
+	if err := ConfigureFuturePublishAction("NULL"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := checkFuturePublishTime("job", &future, now); got != nil {
+		t.Fatalf("expected future time to be dropped, got %v", got)
+	}
+	if err := ConfigureFuturePublishWindow("96h"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := checkFuturePublishTime("job", &future, now); got == nil {
+		t.Fatalf("expected a wider window to keep the time")
+	}
+
+	// Dropped times sort after every dated job under publish_time_desc
+	futurePublishWindow = time.Hour
+	job := sampleJobPayload("future", "Future job")
+	job["publishTime"] = time.Now().UTC().Add(72 * time.Hour).Format(time.RFC3339)
+	records, err := transformDocumentData(map[string]interface{}{"state": map[string]interface{}{"jobDetails": map[string]interface{}{"job": job}}}, "future")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dated := now
+	jobs := []JobRecord{records[0], {ID: "dated", PublishTime: &dated}}
+	sortJobs(jobs, FilterOptions{SortField: SortPublishTime})
+	if records[0].PublishTime != nil || jobs[0].ID != "dated" {
+		t.Fatalf("expected the future job to lose its publish time and sort last, got %+v", jobs)
+	}
+
+	if err := ConfigureFuturePublishAction("clamp"); err == nil {
+		t.Fatalf("expected error for unknown action")
+	}
+	if err := ConfigureFuturePublishWindow("-1h"); err == nil {
+		t.Fatalf("expected error for negative window")
+	}
+}
+
+func TestSortJobsStableAcrossReadOrders(t *testing.T) {
+	published := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := published.Add(time.Hour)
+	budget := 500.0
+	jobs := []JobRecord{
+		{ID: "a", PublishTime: &published, LastVisitedAt: &published, Budget: &BudgetInfo{FixedAmount: &budget}},
+		{ID: "b", PublishTime: &published, LastVisitedAt: &published, Budget: &BudgetInfo{FixedAmount: &budget}},
+		{ID: "c", PublishTime: &later, LastVisitedAt: &later},
+		{ID: "d"},
+		{ID: "e", PublishTime: &published, LastVisitedAt: &published},
+	}
+	ids := func(list []JobRecord) string {
+		parts := make([]string, len(list))
+		for i, job := range list {
+			parts[i] = job.ID
+		}
+		return strings.Join(parts, ",")
+	}
+
+	for _, raw := range []string{"publish_time_desc", "publish_time_asc", "last_visited_desc", "budget_desc", "created_on_asc", "quality_desc", "freshness_desc", "hot"} {
+		opts, err := parseFilterOptions(url.Values{"sort": {raw}})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", raw, err)
+		}
+		// Two reads returning tied documents in different orders
+		first := append([]JobRecord{}, jobs...)
+		second := make([]JobRecord, len(jobs))
+		for i := range jobs {
+			second[i] = jobs[len(jobs)-1-i]
+		}
+		sortJobs(first, opts)
+		sortJobs(second, opts)
+		if ids(first) != ids(second) {
+			t.Fatalf("%s: ordering differs between reads: %s vs %s", raw, ids(first), ids(second))
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"cloud.google.com/go/firestore"
+)
+
+// FieldUsage splits the leaf fields of a raw document into those the
+// transform read and those it ignored. Paths are dotted, with [] marking
+// array elements, e.g. state.job.similarJobs[].title.
+type FieldUsage struct {
+	Consumed []string `json:"consumed"`
+	Ignored  []string `json:"ignored"`
+}
+
+// fieldTracer records map reads made through the util.go accessors. Maps
+// are identified by pointer, so the same key under different parents is
+// attributed to the right path.
+type fieldTracer struct {
+	paths    map[uintptr]string
+	consumed map[string]struct{}
+}
+
+var (
+	activeTracer atomic.Pointer[fieldTracer]
+	traceMu      sync.Mutex
+)
+
+// traceAccess records a read of key from m while a trace is running.
+func traceAccess(m map[string]interface{}, key string) {
+	tracer := activeTracer.Load()
+	if tracer == nil || m == nil {
+		return
+	}
+	if parent, ok := tracer.paths[reflect.ValueOf(m).Pointer()]; ok {
+		tracer.consumed[joinFieldPath(parent, key)] = struct{}{}
+	}
+}
+
+// TraceTransformDocument runs the transform used for doc's collection and
+// reports which source fields it consumed. jobList selects the job_list
+// transform; otherwise similar jobs are traced too, as include_similar would.
+func TraceTransformDocument(doc *firestore.DocumentSnapshot, jobList bool) ([]JobRecord, FieldUsage, error) {
+	return traceTransformData(doc.Data(), doc.Ref.ID, jobList)
+}
+
+func traceTransformData(raw map[string]interface{}, docID string, jobList bool) ([]JobRecord, FieldUsage, error) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	tracer := &fieldTracer{paths: make(map[uintptr]string), consumed: make(map[string]struct{})}
+	leaves := make(map[string]struct{})
+	indexFieldPaths(raw, "", tracer.paths, leaves)
+
+	activeTracer.Store(tracer)
+	var records []JobRecord
+	var err error
+	if jobList {
+		records, err = transformJobListData(raw, docID)
+	} else {
+		records, err = transformDocumentData(raw, docID)
+		similarJobRecords(raw, docID)
+	}
+	activeTracer.Store(nil)
+	if err != nil {
+		return nil, FieldUsage{}, fmt.Errorf("transform failed: %w", err)
+	}
+
+	usage := FieldUsage{Consumed: []string{}, Ignored: []string{}}
+	for leaf := range leaves {
+		if _, ok := tracer.consumed[leaf]; ok {
+			usage.Consumed = append(usage.Consumed, leaf)
+		} else {
+			usage.Ignored = append(usage.Ignored, leaf)
+		}
+	}
+	sort.Strings(usage.Consumed)
+	sort.Strings(usage.Ignored)
+	return records, usage, nil
+}
+
+// indexFieldPaths maps every nested map to its path and collects leaf paths:
+// scalars, arrays without objects and empty objects.
+func indexFieldPaths(value interface{}, path string, paths map[uintptr]string, leaves map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		paths[reflect.ValueOf(v).Pointer()] = path
+		if len(v) == 0 && path != "" {
+			leaves[path] = struct{}{}
+		}
+		for key, child := range v {
+			indexFieldPaths(child, joinFieldPath(path, key), paths, leaves)
+		}
+	case []interface{}:
+		hasObjects := false
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				hasObjects = true
+				indexFieldPaths(m, path+"[]", paths, leaves)
+			}
+		}
+		if !hasObjects {
+			leaves[path] = struct{}{}
+		}
+	default:
+		leaves[path] = struct{}{}
+	}
+}
+
+func joinFieldPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
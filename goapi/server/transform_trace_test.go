@@ -0,0 +1,41 @@
+package server
+
+import "testing"
+
+func TestTraceTransformData(t *testing.T) {
+	job := sampleJobPayload("job-1", "Traced job")
+	job["brandNewField"] = "not mapped yet"
+	doc := map[string]interface{}{
+		"state": map[string]interface{}{
+			"jobDetails": map[string]interface{}{"job": job},
+		},
+	}
+
+	records, usage, err := traceTransformData(doc, "doc-1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+
+	consumed := make(map[string]bool)
+	for _, path := range usage.Consumed {
+		consumed[path] = true
+	}
+	if !consumed["state.jobDetails.job.title"] {
+		t.Fatalf("expected title to be consumed, got %v", usage.Consumed)
+	}
+	ignored := false
+	for _, path := range usage.Ignored {
+		ignored = ignored || path == "state.jobDetails.job.brandNewField"
+	}
+	if !ignored {
+		t.Fatalf("expected brandNewField to be ignored, got %v", usage.Ignored)
+	}
+
+	// Tracing is off outside a trace
+	if activeTracer.Load() != nil {
+		t.Fatalf("expected tracer to be cleared")
+	}
+}
@@ -12,8 +12,18 @@ const (
 	SortLastVisited sortField = "last_visited"
 	SortPublishTime sortField = "publish_time"
 	SortBudget      sortField = "budget"
+	SortCreatedOn   sortField = "created_on"
+	SortHot         sortField = "hot"
+	SortQuality     sortField = "quality"
+	SortFreshness   sortField = "freshness" // later of publish time and last visit
 )
 
+// sortKey is one entry of a possibly multi-field sort.
+type sortKey struct {
+	Field     sortField
+	Ascending bool
+}
+
 var enumKeyReplacer = strings.NewReplacer("-", "", "_", "", " ", "")
 
 var (
@@ -87,6 +97,7 @@ type JobRecord struct {
 	ClientActivity       *ClientActivity
 	Location             *JobLocation
 	IsPrivate            bool
+	PrivacyStatus        string
 	PrivacyReason        string
 	Ciphertext           string
 	Workload             string
@@ -101,6 +112,9 @@ type JobRecord struct {
 	WeeklyRetainerBudget *BudgetInfo
 	Occupations          []string
 	Recno                *int64
+	HotScore             float64  // set by sortJobs for sort=hot
+	FromSimilar          bool     // extracted from another job's similarJobs
+	QualityScore         *float64 // 0-100 client rating, see qualityScore
 }
 
 // JobDTO is the API response schema.
@@ -128,6 +142,7 @@ type JobDTO struct {
 	ClientActivity       *ClientActivity    `json:"client_activity,omitempty"`
 	Location             *JobLocation       `json:"location,omitempty"`
 	IsPrivate            bool               `json:"is_private,omitempty"`
+	PrivacyStatus        string             `json:"privacy_status,omitempty"`
 	PrivacyReason        string             `json:"privacy_reason,omitempty"`
 	Ciphertext           string             `json:"ciphertext,omitempty"`
 	Workload             string             `json:"workload,omitempty"`
@@ -142,12 +157,27 @@ type JobDTO struct {
 	WeeklyRetainerBudget *BudgetInfo        `json:"weekly_retainer_budget,omitempty"`
 	Occupations          []string           `json:"occupations,omitempty"`
 	Recno                *int64             `json:"recno,omitempty"`
+	FromSimilar          bool               `json:"from_similar,omitempty"`
+	QualityScore         *float64           `json:"quality_score,omitempty"`
 }
 
 // BudgetInfo describes job budget metadata.
 type BudgetInfo struct {
 	FixedAmount *float64 `json:"fixed_amount,omitempty"`
 	Currency    string   `json:"currency,omitempty"`
+	// FixedAmountDisplay is set only when format_currency=true, e.g. "$1,200"
+	FixedAmountDisplay string `json:"fixed_amount_display,omitempty"`
+	// Secondary holds the other source's budget when the document's budget
+	// and amount maps disagree on currency
+	Secondary *SecondaryBudget `json:"secondary,omitempty"`
+}
+
+// SecondaryBudget is a budget in a conflicting currency. Source names the
+// document map it came from ("budget" or "amount").
+type SecondaryBudget struct {
+	FixedAmount *float64 `json:"fixed_amount,omitempty"`
+	Currency    string   `json:"currency"`
+	Source      string   `json:"source"`
 }
 
 type HourlyBudget struct {
@@ -163,6 +193,46 @@ type JobsResponse struct {
 	Count       int      `json:"count"`
 	LastUpdated string   `json:"last_updated"`
 	Message     string   `json:"message,omitempty"`
+	ErrorCode   string   `json:"error_code,omitempty"`
+	// MatchedInFetch counts fetched documents that passed the filters,
+	// before offset and limit. Fetches stop at 500 documents, so when
+	// MatchedLowerBound is set more jobs may match than reported.
+	MatchedInFetch    *int `json:"matched_in_fetch,omitempty"`
+	MatchedLowerBound bool `json:"matched_is_lower_bound,omitempty"`
+	// IDs replaces Data when ids_only=true
+	IDs []string `json:"ids,omitempty"`
+	// Partial is set when the query deadline cut the scan short
+	Partial bool `json:"partial,omitempty"`
+	// LastModified is the newest last_visited_at among the returned jobs
+	LastModified string `json:"last_modified,omitempty"`
+	// RelaxedFilters lists filters dropped to reach min_results, in order
+	RelaxedFilters []string `json:"relaxed_filters,omitempty"`
+	// Skipped counts dropped documents by reason for admin debug=true requests
+	Skipped *SkipSummary `json:"skipped,omitempty"`
+}
+
+// JobsBatchRequest is the body accepted by POST /jobs/batch.
+type JobsBatchRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// JobResponse returns a single job. Raw is only set for admin include_raw requests.
+type JobResponse struct {
+	Success     bool                   `json:"success"`
+	Data        JobDTO                 `json:"data"`
+	Raw         map[string]interface{} `json:"raw,omitempty"`
+	LastUpdated string                 `json:"last_updated"`
+	Message     string                 `json:"message,omitempty"`
+}
+
+// JobsBatchResponse returns the requested jobs keyed by ID.
+type JobsBatchResponse struct {
+	Success     bool              `json:"success"`
+	Data        map[string]JobDTO `json:"data"`
+	Missing     []string          `json:"missing"`
+	Count       int               `json:"count"`
+	LastUpdated string            `json:"last_updated"`
+	Message     string            `json:"message,omitempty"`
 }
 
 // CategoryInfo provides category context.
@@ -186,6 +256,7 @@ type BuyerInfo struct {
 	FeedbackCount      *int
 	TotalHours         *float64
 	Score              *float64
+	CompanyName        string
 	CompanyIndustry    string
 	CompanySize        *int
 	ContractDate       *time.Time
@@ -205,6 +276,7 @@ type BuyerDTO struct {
 	FeedbackCount      *int     `json:"feedback_count,omitempty"`
 	TotalHours         *float64 `json:"total_hours,omitempty"`
 	Score              *float64 `json:"score,omitempty"`
+	CompanyName        string   `json:"company_name,omitempty"`
 	CompanyIndustry    string   `json:"company_industry,omitempty"`
 	CompanySize        *int     `json:"company_size,omitempty"`
 	ContractDate       string   `json:"contract_date,omitempty"`
@@ -229,6 +301,7 @@ func (b *BuyerInfo) ToDTO() *BuyerDTO {
 		FeedbackCount:      b.FeedbackCount,
 		TotalHours:         b.TotalHours,
 		Score:              b.Score,
+		CompanyName:        b.CompanyName,
 		CompanyIndustry:    b.CompanyIndustry,
 		CompanySize:        b.CompanySize,
 		OpenJobsCount:      b.OpenJobsCount,
@@ -244,10 +317,12 @@ func (b *BuyerInfo) ToDTO() *BuyerDTO {
 type ClientActivity struct {
 	TotalApplicants         *int   `json:"total_applicants,omitempty"`
 	TotalHired              *int   `json:"total_hired,omitempty"`
-	TotalInvitedToInterview *int   `json:"total_invited_to_interview,omitempty"`
+	TotalInvitedToInterview *int   `json:"total_invited_to_interview,omitempty"` // interviews under way signal active hiring
 	UnansweredInvites       *int   `json:"unanswered_invites,omitempty"`
 	InvitationsSent         *int   `json:"invitations_sent,omitempty"`
 	LastBuyerActivity       string `json:"last_buyer_activity,omitempty"`
+	// LastBuyerActivityAt is LastBuyerActivity parsed; nil when absent or unparseable
+	LastBuyerActivityAt *time.Time `json:"-"`
 }
 
 type JobLocation struct {
@@ -266,8 +341,19 @@ type JobQualifications struct {
 	MinHoursWeek        *float64 `json:"min_hours_week,omitempty"`
 }
 
+// DTOOptions tunes how a JobRecord is rendered.
+type DTOOptions struct {
+	FormatCurrency bool           // add display strings to budget amounts
+	Location       *time.Location // zone for emitted timestamps; nil means UTC
+}
+
 // ToDTO converts a JobRecord into response form.
 func (job JobRecord) ToDTO() JobDTO {
+	return job.ToDTOWith(DTOOptions{})
+}
+
+// ToDTOWith converts a JobRecord into response form using opts.
+func (job JobRecord) ToDTOWith(opts DTOOptions) JobDTO {
 	dto := JobDTO{
 		ID:                   job.ID,
 		Title:                job.Title,
@@ -287,6 +373,7 @@ func (job JobRecord) ToDTO() JobDTO {
 		ClientActivity:       job.ClientActivity,
 		Location:             job.Location,
 		IsPrivate:            job.IsPrivate,
+		PrivacyStatus:        job.PrivacyStatus,
 		PrivacyReason:        job.PrivacyReason,
 		Ciphertext:           job.Ciphertext,
 		Workload:             job.Workload,
@@ -301,21 +388,31 @@ func (job JobRecord) ToDTO() JobDTO {
 		WeeklyRetainerBudget: job.WeeklyRetainerBudget,
 		Occupations:          job.Occupations,
 		Recno:                job.Recno,
+		FromSimilar:          job.FromSimilar,
+		QualityScore:         job.QualityScore,
 	}
 
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
 	if job.PostedOn != nil {
-		dto.PostedOn = job.PostedOn.UTC().Format(time.RFC3339)
+		dto.PostedOn = job.PostedOn.In(loc).Format(time.RFC3339)
 	}
 	if job.CreatedOn != nil {
-		dto.CreatedOn = job.CreatedOn.UTC().Format(time.RFC3339)
+		dto.CreatedOn = job.CreatedOn.In(loc).Format(time.RFC3339)
 	}
 	if job.PublishTime != nil {
 		publishTime := job.PublishTime.UTC()
-		dto.PublishTime = publishTime.Format(time.RFC3339)
+		dto.PublishTime = publishTime.In(loc).Format(time.RFC3339)
 		dto.PublishTimeRelative = formatRelativeTime(publishTime)
 	}
 	if job.LastVisitedAt != nil {
-		dto.LastVisitedAt = job.LastVisitedAt.UTC().Format(time.RFC3339)
+		dto.LastVisitedAt = job.LastVisitedAt.In(loc).Format(time.RFC3339)
+	}
+	if opts.FormatCurrency {
+		dto.Budget = job.Budget.withDisplay()
+		dto.WeeklyRetainerBudget = job.WeeklyRetainerBudget.withDisplay()
 	}
 
 	return dto
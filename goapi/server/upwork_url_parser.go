@@ -36,13 +36,19 @@ func ParseUpworkSearchURL(raw string) (url.Values, error) {
 		}
 
 		switch strings.ToLower(key) {
-		case "q":
+		case "q", "search":
+			if existing := result.Get("search"); existing != "" && searchValuesConflict(existing, value) {
+				return nil, fmt.Errorf("conflicting q and search values; send only one")
+			}
 			result.Set("search", value)
 		case "payment_verified", "client_payment_verification_status":
 			if parsedBool, ok := parseUpworkBool(value); ok {
 				result.Set("payment_verified", strconv.FormatBool(parsedBool))
 			}
 		case "t", "job_type":
+			if existing := result.Get("t"); existing != "" && jobTypeValuesConflict(existing, value) {
+				return nil, fmt.Errorf("conflicting t and job_type values; send only one")
+			}
 			result.Set("t", value)
 		case "contractor_tier", "exp_level", "experience_level":
 			if tier := parseUpworkContractorTier(value); tier != "" {
@@ -59,11 +65,14 @@ func ParseUpworkSearchURL(raw string) (url.Values, error) {
 		case "hourly_rate", "hourly":
 			result.Set("hourly_rate", value)
 		case "amount", "fixed_budget":
-			result.Set("amount", value)
+			// Budget ranges come comma-separated (amount=0-99,100-499) or as
+			// repeated keys; both become one comma-separated list of ranges
+			result.Set("amount", normalizeCommaSeparated(strings.Join(values, ",")))
 		case "client_hires":
 			result.Set("client_hires", value)
 		case "location":
-			result.Set("location", value)
+			// Multiple regions/countries are comma-separated and OR'ed
+			result.Set("location", normalizeCommaSeparated(value))
 		case "timezone":
 			result.Set("timezone", value)
 		case "workload":
@@ -92,25 +101,39 @@ func ParseUpworkSearchURL(raw string) (url.Values, error) {
 }
 
 var supportedAPIParams = map[string]struct{}{
-	"limit":            {},
-	"offset":           {},
-	"payment_verified": {},
-	"amount":           {},
-	"client_hires":     {},
-	"contract_to_hire": {},
-	"contractor_tier":  {},
-	"duration_v3":      {},
-	"hourly_rate":      {},
-	"location":         {},
-	"previous_clients": {},
-	"proposals":        {},
-	"sort":             {},
-	"subcategory2_uid": {},
-	"t":                {},
-	"timezone":         {},
-	"workload":         {},
-	"search":           {},
-	"q":                {},
+	"limit":               {},
+	"offset":              {},
+	"payment_verified":    {},
+	"amount":              {},
+	"buyer_active_within": {},
+	"client_hires":        {},
+	"client_reviews":      {},
+	"client_spend_tier":   {},
+	"company_size":        {},
+	"industry":            {},
+	"invitations":         {},
+	"interviewing":        {},
+	"contract_to_hire":    {},
+	"country_exclude":     {},
+	"exclude":             {},
+	"contractor_tier":     {},
+	"duration_v3":         {},
+	"has_category":        {},
+	"require_title":       {},
+	"has_fixed":           {},
+	"has_hourly":          {},
+	"hourly_rate":         {},
+	"location":            {},
+	"min_pay":             {},
+	"previous_clients":    {},
+	"proposals":           {},
+	"sort":                {},
+	"subcategory2_uid":    {},
+	"t":                   {},
+	"timezone":            {},
+	"workload":            {},
+	"search":              {},
+	"q":                   {},
 }
 
 func parseUpworkBool(value string) (bool, bool) {
@@ -284,25 +307,44 @@ func parseUpworkProposalsTier(value string) string {
 	return normalized
 }
 
+// upworkSortModes maps Upwork's sort labels to the closest API sort mode.
+var upworkSortModes = map[string]string{
+	"recency":             "publish_time_desc",
+	"relevance":           "publish_time_desc",
+	"client_rating":       "last_visited_desc",
+	"duration":            "publish_time_desc",
+	"budget":              "budget_desc",
+	"duration_asc":        "publish_time_asc",
+	"client_spend":        "budget_desc",
+	"client_total_charge": "budget_desc",
+	"client_recent":       "last_visited_desc",
+}
+
 func parseUpworkSort(value string) string {
 	normalized := strings.ToLower(strings.TrimSpace(value))
-	sortMap := map[string]string{
-		"recency":       "publish_time_desc",
-		"relevance":     "publish_time_desc",
-		"client_rating": "last_visited_desc",
-		"duration":      "publish_time_desc",
-		"budget":        "budget_desc",
-		"duration_asc":  "publish_time_asc",
-		"client_spend":  "budget_desc",
-		"client_recent": "last_visited_desc",
-	}
-
-	if mapped, ok := sortMap[normalized]; ok {
+	if mapped, ok := upworkSortModes[normalized]; ok {
 		return mapped
 	}
 	return normalized
 }
 
+// cutUpworkSortDirection splits a normalized Upwork sort such as
+// "client_ratingdesc" (client_rating+desc after URL decoding) into its
+// label and direction.
+func cutUpworkSortDirection(normalized string) (string, bool, bool) {
+	for _, suffix := range []string{"+desc", "desc"} {
+		if label, ok := strings.CutSuffix(normalized, suffix); ok && label != "" {
+			return label, false, true
+		}
+	}
+	for _, suffix := range []string{"+asc", "asc"} {
+		if label, ok := strings.CutSuffix(normalized, suffix); ok && label != "" {
+			return label, true, true
+		}
+	}
+	return "", false, false
+}
+
 func parseUpworkCreatedTime(value string) string {
 	normalized := strings.ToUpper(strings.TrimSpace(value))
 	now := time.Now().UTC()
@@ -85,3 +85,43 @@ func TestParseUpworkSearchURLRequiresAbsoluteURL(t *testing.T) {
 		t.Fatalf("expected error for relative URL, got nil")
 	}
 }
+
+func TestParseUpworkSearchURLMultiRangeAmount(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"comma separated", "https://www.upwork.com/nx/search/jobs/?amount=0-99,100-499", "0-99,100-499"},
+		{"encoded comma", "https://www.upwork.com/nx/search/jobs/?amount=-99%2C1000-", "-99,1000-"},
+		{"repeated keys", "https://www.upwork.com/nx/search/jobs/?amount=0-99&amount=500-999", "0-99,500-999"},
+	}
+
+	for _, tt := range tests {
+		derived, err := ParseUpworkSearchURL(tt.raw)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got := derived.Get("amount"); got != tt.want {
+			t.Fatalf("%s: expected amount %q, got %q", tt.name, tt.want, got)
+		}
+
+		opts, err := convertToFilterOptions(&JobsQueryParams{UpworkURL: tt.raw, derivedParams: derived})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if len(opts.BudgetRanges) != 2 {
+			t.Fatalf("%s: expected 2 budget ranges, got %+v", tt.name, opts.BudgetRanges)
+		}
+	}
+
+	// Ranges are OR'ed: a budget in either range matches, one in the gap does not
+	derived, _ := ParseUpworkSearchURL(tests[0].raw)
+	opts, _ := convertToFilterOptions(&JobsQueryParams{UpworkURL: tests[0].raw, derivedParams: derived})
+	budget := func(amount float64) *JobRecord {
+		return &JobRecord{Budget: &BudgetInfo{FixedAmount: &amount}}
+	}
+	if !applyFilters(budget(50), opts) || !applyFilters(budget(250), opts) || applyFilters(budget(750), opts) {
+		t.Fatalf("expected budgets 50 and 250 to match amount=0-99,100-499 and 750 not to")
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpworkURLParseResponse shows how /jobs would read an Upwork search URL.
+type UpworkURLParseResponse struct {
+	Success bool   `json:"success"`
+	URL     string `json:"url"`
+	// Params are the query parameters derived from the URL
+	Params map[string]string `json:"params"`
+	// Filters are the resolved filter options, defaults included
+	Filters     map[string]string `json:"filters"`
+	LastUpdated string            `json:"last_updated"`
+}
+
+// handleUpworkURLParse previews the filters an Upwork URL maps to.
+// @Summary Preview Upwork URL filters
+// @Description Runs the /jobs URL parser on `url` and returns the derived query parameters and the resolved filters (with defaults such as limit and sort applied) without querying jobs. Invalid URLs and filter values return 400 with the reason in `message`.
+// @Tags jobs
+// @Produce json
+// @Param url query string true "Full Upwork job search URL" example(https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40)
+// @Success 200 {object} UpworkURLParseResponse
+// @Failure 400 {object} JobsResponse
+// @Failure 401 {object} JobsResponse
+// @Security ApiKeyAuth
+// @Router /upwork-url/parse [get]
+func (s *Server) handleUpworkURLParse(c *gin.Context) {
+	raw := strings.TrimSpace(c.Query("url"))
+	if raw == "" {
+		respondError(c, http.StatusBadRequest, "url is required")
+		return
+	}
+
+	derived, err := ParseUpworkSearchURL(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid url: "+err.Error())
+		return
+	}
+
+	opts, err := convertToFilterOptions(&JobsQueryParams{UpworkURL: raw, derivedParams: derived})
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	params := make(map[string]string, len(derived))
+	for key := range derived {
+		params[key] = derived.Get(key)
+	}
+
+	c.JSON(http.StatusOK, UpworkURLParseResponse{
+		Success:     true,
+		URL:         raw,
+		Params:      params,
+		Filters:     filterOptionsMap(opts),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// filterOptionsMap keys filterOptionParts by parameter name. The upwork_url
+// part is dropped since the response already echoes it.
+func filterOptionsMap(opts FilterOptions) map[string]string {
+	filters := make(map[string]string)
+	for _, part := range filterOptionParts(opts) {
+		name, value, _ := strings.Cut(part, "=")
+		if name == "upwork_url" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		filters[name] = value
+	}
+	return filters
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleUpworkURLParse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := &Server{}
+
+	parse := func(raw string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodGet, "/upwork-url/parse?url="+url.QueryEscape(raw), nil)
+		srv.handleUpworkURLParse(c)
+		return rec
+	}
+
+	rec := parse("https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40&proposals=50%2B")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp UpworkURLParseResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Params["search"] != "python" || resp.Params["proposals"] != "50-" {
+		t.Fatalf("unexpected params: %+v", resp.Params)
+	}
+	if resp.Filters["search"] != "python" || resp.Filters["hourly_rate"] != "20.00-40.00" || resp.Filters["sort"] != "publish_time_desc" {
+		t.Fatalf("unexpected filters: %+v", resp.Filters)
+	}
+	if _, ok := resp.Filters["limit"]; !ok {
+		t.Fatalf("expected the default limit to be resolved: %+v", resp.Filters)
+	}
+
+	for _, raw := range []string{"", "/nx/search/jobs/?q=python", "https://www.upwork.com/nx/search/jobs/?hourly_rate=abc"} {
+		rec := parse(raw)
+		var errResp JobsResponse
+		if rec.Code != http.StatusBadRequest || json.Unmarshal(rec.Body.Bytes(), &errResp) != nil || errResp.Message == "" {
+			t.Fatalf("%q: expected a 400 with a message, got %d: %s", raw, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"os"
 	"strconv"
@@ -28,6 +29,20 @@ func mustEnv(key string) string {
 	return value
 }
 
+// envPositiveInt reads a positive integer from the environment, returning
+// fallback when the variable is unset.
+func envPositiveInt(key string, fallback int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be a positive integer", key)
+	}
+	return value, nil
+}
+
 func maskAPIKey(value string) string {
 	if value == "" {
 		return "(empty)"
@@ -77,6 +92,7 @@ func getMap(root map[string]interface{}, keys ...string) map[string]interface{}
 		if current == nil {
 			return nil
 		}
+		traceAccess(current, key)
 		value, ok := current[key]
 		if !ok {
 			return nil
@@ -119,6 +135,7 @@ func getString(m map[string]interface{}, key string) string {
 	if m == nil {
 		return ""
 	}
+	traceAccess(m, key)
 	if value, ok := m[key]; ok {
 		if s, ok := value.(string); ok {
 			return s
@@ -145,6 +162,7 @@ func dig(root interface{}, keys ...string) (interface{}, bool) {
 		if !ok {
 			return nil, false
 		}
+		traceAccess(m, key)
 		value, ok := m[key]
 		if !ok {
 			return nil, false
@@ -158,6 +176,7 @@ func getIntPointer(m map[string]interface{}, key string) *int {
 	if m == nil {
 		return nil
 	}
+	traceAccess(m, key)
 	if value, ok := m[key]; ok {
 		if intVal, ok := toInt(value); ok {
 			return &intVal
@@ -175,6 +194,15 @@ func extractInt(m map[string]interface{}, key string) (int, bool) {
 	return 0, false
 }
 
+// extractInt64 is extractInt for identifiers such as recno that may exceed
+// 2^53, where a float64 round trip would change the value.
+func extractInt64(m map[string]interface{}, key string) (int64, bool) {
+	if value, ok := dig(m, key); ok {
+		return toInt64(value)
+	}
+	return 0, false
+}
+
 func extractFloat(m map[string]interface{}, key string) (float64, bool) {
 	if value, ok := dig(m, key); ok {
 		return toFloat64(value)
@@ -283,6 +311,33 @@ func toInt(value interface{}) (int, bool) {
 	return 0, false
 }
 
+// toInt64 converts value to an int64 without going through float64 when the
+// source is already integral: int64, json.Number and decimal strings keep
+// every digit. Floats are truncated like toInt does.
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+	case string:
+		if i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return i, true
+		}
+	case float64:
+		if v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), true
+		}
+	case float32:
+		return toInt64(float64(v))
+	}
+	return 0, false
+}
+
 func toFloat64(value interface{}) (float64, bool) {
 	switch v := value.(type) {
 	case float64:
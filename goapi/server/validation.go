@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -27,10 +28,61 @@ type ValidationErrorResponse struct {
 // JobsQueryParams defines the validated query parameters for /jobs endpoint
 type JobsQueryParams struct {
 	UpworkURL string `form:"upwork_url" binding:"required,url"`
+	// CacheTTL overrides the response cache TTL; honoured for admin keys only
+	CacheTTL string `form:"cache_ttl"`
+	// Debug=true adds skip-reason counts to the response; admin keys only
+	Debug string `form:"debug"`
+	// StrictOrder=false includes documents missing the sort field
+	StrictOrder string `form:"strict_order"`
+	// MaxStaleness overrides the server's MAX_JOB_STALENESS ("0" disables)
+	MaxStaleness string `form:"max_staleness"`
+	// IncludeSimilar=true adds similar jobs listed on private job pages
+	IncludeSimilar string `form:"include_similar"`
+	// FormatCurrency=true adds display strings such as "$1,200" to budgets
+	FormatCurrency string `form:"format_currency"`
+	// Case selects response key casing: snake (default) or camel
+	Case string `form:"case" binding:"omitempty,oneof=snake camel"`
+	// IDsOnly=true returns matching job IDs instead of job objects
+	IDsOnly string `form:"ids_only"`
+	// Stem=true also matches search terms by word stem
+	Stem string `form:"stem"`
+	// TZ is an IANA zone for emitted timestamps; invalid zones fall back to UTC
+	TZ string `form:"tz"`
+	// SinceID keeps only jobs published after the referenced job
+	SinceID string `form:"since_id"`
+	// MinResults relaxes filters until at least this many jobs match
+	MinResults string `form:"min_results"`
 
 	derivedParams url.Values `form:"-"`
 }
 
+// jobsControlParams are top-level parameters accepted alongside upwork_url.
+// They tune how the request is served rather than which jobs are returned.
+var jobsControlParams = map[string]struct{}{
+	"cache_ttl":       {},
+	"case":            {},
+	"debug":           {},
+	"format_currency": {},
+	"ids_only":        {},
+	"include_similar": {},
+	"since_id":        {},
+	"stem":            {},
+	"tz":              {},
+	"max_staleness":   {},
+	"min_results":     {},
+	"strict_order":    {},
+}
+
+// controlParamNames returns the accepted control parameters in sorted order
+func controlParamNames() []string {
+	names := make([]string, 0, len(jobsControlParams))
+	for name := range jobsControlParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // RegisterCustomValidators registers custom validators with gin's validator
 func RegisterCustomValidators(v *validator.Validate) {
 	v.RegisterValidation("job_type_enum", validateJobType)
@@ -104,16 +156,29 @@ func validateSortField(fl validator.FieldLevel) bool {
 		"publish_time_asc", "publish_time_desc",
 		"last_visited_asc", "last_visited_desc",
 		"budget_asc", "budget_desc",
+		"created_on_asc", "created_on_desc",
+		"hot",
+		"quality_asc", "quality_desc",
+		"freshness_asc", "freshness_desc",
 		"posted_on_asc", "posted_on_desc", // aliases
 	}
 
-	for _, field := range validSortFields {
-		if value == field {
-			return true
+	// Multi-field sorts are comma-separated; every entry must be valid
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		valid := false
+		for _, field := range validSortFields {
+			if entry == field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return false
 		}
 	}
 
-	return false
+	return true
 }
 
 // FormatValidationErrors converts validator errors into LLM-friendly messages
@@ -208,35 +273,73 @@ func formatFieldError(fieldErr validator.FieldError) string {
 	case "contractor_tier_enum":
 		return fmt.Sprintf("The '%s' field must be a valid contractor tier. Accepted values: 'entry', 'intermediate', 'expert', or numeric codes (1=entry, 2=intermediate, 3=expert).", field)
 	case "sort_field":
-		return fmt.Sprintf("The '%s' field must be a valid sort field. Accepted values: 'publish_time_asc', 'publish_time_desc', 'last_visited_asc', 'last_visited_desc', 'budget_asc', 'budget_desc'.", field)
+		return fmt.Sprintf("The '%s' field must be a valid sort field. Accepted values: 'publish_time_asc', 'publish_time_desc', 'last_visited_asc', 'last_visited_desc', 'budget_asc', 'budget_desc', 'created_on_asc', 'created_on_desc', 'quality_asc', 'quality_desc', 'freshness_asc', 'freshness_desc', 'hot'. Combine several with commas, e.g. 'publish_time_desc,budget_desc'.", field)
 	default:
 		return fmt.Sprintf("The '%s' field failed validation: %s.", field, tag)
 	}
 }
 
-// getFieldExample provides an example value for the field
+// jobsParamExamples holds one example value per /jobs parameter. Entries for
+// upwork_url and the control parameters must match the example(...) values in
+// the handleJobs @Param annotations; the rest are filters carried inside
+// upwork_url and are listed in its @Description.
+var jobsParamExamples = map[string]string{
+	"upwork_url":      "https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40",
+	"cache_ttl":       "30s",
+	"debug":           "true",
+	"strict_order":    "false",
+	"include_similar": "true",
+	"format_currency": "true",
+	"case":            "camel",
+	"ids_only":        "true",
+	"stem":            "true",
+	"since_id":        "1971966031232790280",
+	"tz":              "America/New_York",
+	"max_staleness":   "30d",
+	"min_results":     "5",
+
+	// Filters inside upwork_url
+	"q":                   "python",
+	"search":              "(python AND automation)",
+	"exclude":             "wordpress,php",
+	"limit":               "20",
+	"offset":              "20",
+	"payment_verified":    "1",
+	"t":                   "hourly",
+	"contractor_tier":     "2",
+	"contract_to_hire":    "true",
+	"has_category":        "true",
+	"require_title":       "true",
+	"has_hourly":          "true",
+	"has_fixed":           "true",
+	"duration_v3":         "week,month",
+	"workload":            "part_time",
+	"amount":              "500-2000",
+	"hourly_rate":         "25-75",
+	"min_pay":             "50",
+	"client_hires":        "1-9",
+	"client_spend_tier":   "10k",
+	"buyer_active_within": "7d",
+	"client_reviews":      "10-",
+	"company_size":        "1-10,1000-",
+	"industry":            "tech-it,health-fitness",
+	"invitations":         "0-2",
+	"interviewing":        "true",
+	"location":            "United States",
+	"country_exclude":     "India,PK",
+	"timezone":            "America/New_York",
+	"proposals":           "0-4",
+	"previous_clients":    "all",
+	"subcategory2_uid":    "531770282580668418",
+	"sort":                "publish_time_desc,budget_desc",
+}
+
+// getFieldExample provides an example request for the field
 func getFieldExample(fieldErr validator.FieldError) string {
 	field := getFieldName(fieldErr)
 	tag := fieldErr.Tag()
 
-	examples := map[string]string{
-		"upwork_url":       "?upwork_url=https://www.upwork.com/nx/search/jobs/?q=python&hourly_rate=20-40",
-		"limit":            "?limit=20",
-		"offset":           "?offset=0",
-		"payment_verified": "?payment_verified=true",
-		"country":          "?country=US",
-		"skills":           "?skills=python,react",
-		"job_type":         "?job_type=hourly",
-		"duration":         "?duration=short-term",
-		"hourly_min":       "?hourly_min=25",
-		"hourly_max":       "?hourly_max=75",
-		"budget_min":       "?budget_min=500",
-		"budget_max":       "?budget_max=2000",
-		"search":           "?search=(python AND automation)",
-		"sort":             "?sort=publish_time_desc",
-	}
-
-	if example, exists := examples[field]; exists {
+	if example := jobsParamExample(field); example != "" {
 		return example
 	}
 
@@ -256,6 +359,22 @@ func getFieldExample(fieldErr validator.FieldError) string {
 	}
 }
 
+// jobsParamExample renders an example /jobs query string for param, placing
+// filters inside upwork_url. It returns "" for unknown parameters.
+func jobsParamExample(param string) string {
+	value, ok := jobsParamExamples[param]
+	if !ok {
+		return ""
+	}
+	if param == "upwork_url" {
+		return "?upwork_url=" + value
+	}
+	if _, isControl := jobsControlParams[param]; isControl {
+		return fmt.Sprintf("?upwork_url=%s&%s=%s", jobsParamExamples["upwork_url"], param, value)
+	}
+	return fmt.Sprintf("?upwork_url=https://www.upwork.com/nx/search/jobs/?%s=%s", param, value)
+}
+
 // ValidateAndBindJobsQuery validates and binds the jobs query parameters
 func ValidateAndBindJobsQuery(c *gin.Context) (*JobsQueryParams, error) {
 	var params JobsQueryParams
@@ -265,12 +384,26 @@ func ValidateAndBindJobsQuery(c *gin.Context) (*JobsQueryParams, error) {
 	}
 
 	params.UpworkURL = strings.TrimSpace(params.UpworkURL)
+	params.CacheTTL = strings.TrimSpace(params.CacheTTL)
+	params.Debug = strings.TrimSpace(params.Debug)
+	params.StrictOrder = strings.TrimSpace(params.StrictOrder)
+	params.MaxStaleness = strings.TrimSpace(params.MaxStaleness)
+	params.IncludeSimilar = strings.TrimSpace(params.IncludeSimilar)
+	params.FormatCurrency = strings.TrimSpace(params.FormatCurrency)
+	params.IDsOnly = strings.TrimSpace(params.IDsOnly)
+	params.Stem = strings.TrimSpace(params.Stem)
+	params.SinceID = strings.TrimSpace(params.SinceID)
+	params.MinResults = strings.TrimSpace(params.MinResults)
+	params.TZ = strings.TrimSpace(params.TZ)
 
 	for key := range c.Request.URL.Query() {
 		if strings.EqualFold(key, "upwork_url") {
 			continue
 		}
-		return nil, fmt.Errorf("parameter '%s' is not supported. Only 'upwork_url' may be provided.", key)
+		if _, ok := jobsControlParams[strings.ToLower(key)]; ok {
+			continue
+		}
+		return nil, fmt.Errorf("parameter '%s' is not supported. Only 'upwork_url' may be provided (control parameters: %s).", key, strings.Join(controlParamNames(), ", "))
 	}
 
 	derived, err := ParseUpworkSearchURL(params.UpworkURL)
@@ -299,6 +432,30 @@ func convertToFilterOptions(params *JobsQueryParams) (FilterOptions, error) {
 	}
 
 	combined.Set("upwork_url", params.UpworkURL)
+	if params.StrictOrder != "" {
+		combined.Set("strict_order", params.StrictOrder)
+	}
+	if params.MaxStaleness != "" {
+		combined.Set("max_staleness", params.MaxStaleness)
+	}
+	if params.IncludeSimilar != "" {
+		combined.Set("include_similar", params.IncludeSimilar)
+	}
+	if params.FormatCurrency != "" {
+		combined.Set("format_currency", params.FormatCurrency)
+	}
+	if params.IDsOnly != "" {
+		combined.Set("ids_only", params.IDsOnly)
+	}
+	if params.Stem != "" {
+		combined.Set("stem", params.Stem)
+	}
+	if params.TZ != "" {
+		combined.Set("tz", params.TZ)
+	}
+	if params.MinResults != "" {
+		combined.Set("min_results", params.MinResults)
+	}
 
 	opts, err := parseFilterOptions(combined)
 	if err != nil {